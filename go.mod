@@ -6,7 +6,6 @@ require (
 	github.com/google/go-querystring v1.2.0
 	github.com/google/uuid v1.6.0
 	github.com/gorilla/websocket v1.5.3
-	github.com/patrickmn/go-cache v2.1.0+incompatible
 	github.com/samber/mo v1.17.0
 	github.com/stretchr/testify v1.12.0
 	gopkg.in/yaml.v3 v3.0.1
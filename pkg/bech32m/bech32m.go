@@ -196,3 +196,23 @@ func DecodePuzzleHash(addr string) (string, types.Bytes32, error) {
 	}
 	return hrp, b, nil
 }
+
+// DIDPrefix is the prefix of did:chia: identifiers, which encode the launcher ID of a DID
+const DIDPrefix = "did:chia:"
+
+// EncodeDID encodes a DID launcher ID as a did:chia: identifier
+func EncodeDID(launcherID types.Bytes32) (string, error) {
+	return EncodePuzzleHash(launcherID, DIDPrefix)
+}
+
+// DecodeDID decodes a did:chia: identifier to the launcher ID of the DID
+func DecodeDID(did string) (types.Bytes32, error) {
+	hrp, launcherID, err := DecodePuzzleHash(did)
+	if err != nil {
+		return types.Bytes32{}, err
+	}
+	if hrp != DIDPrefix {
+		return types.Bytes32{}, fmt.Errorf("not a DID, expected the %s prefix but found %s", DIDPrefix, hrp)
+	}
+	return launcherID, nil
+}
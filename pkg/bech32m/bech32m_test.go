@@ -45,3 +45,20 @@ func TestKnownAddressConversions(t *testing.T) {
 		}
 	}
 }
+
+func TestDIDConversions(t *testing.T) {
+	launcherID, err := types.Bytes32FromHexString("0x00000000000000000000000000000000000000000000000000000000000dead0")
+	assert.NoError(t, err)
+
+	did, err := bech32m.EncodeDID(launcherID)
+	assert.NoError(t, err)
+	assert.Equal(t, "did:chia:1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqdatgqatz0n5", did)
+
+	decoded, err := bech32m.DecodeDID(did)
+	assert.NoError(t, err)
+	assert.Equal(t, launcherID, decoded)
+
+	// Addresses are valid bech32m, but not DIDs
+	_, err = bech32m.DecodeDID("xch1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqm6ks6e8mvy")
+	assert.Error(t, err)
+}
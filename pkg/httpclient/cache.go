@@ -3,36 +3,319 @@ package httpclient
 import (
 	"bufio"
 	"bytes"
+	"container/list"
+	"context"
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httputil"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/patrickmn/go-cache"
+	"github.com/chia-network/go-chia-libs/pkg/metrics"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
 )
 
+const (
+	// DefaultCacheMaxEntries is the number of responses kept in the cache when the policy doesn't set MaxEntries
+	DefaultCacheMaxEntries = 1000
+
+	// DefaultCacheMaxBytes is the total size of the responses kept in the cache when the policy doesn't set MaxBytes
+	DefaultCacheMaxBytes = 64 << 20
+
+	// DefaultCacheMaxResponseBytes is the largest response that is cached when the policy doesn't set MaxResponseBytes
+	DefaultCacheMaxResponseBytes = 4 << 20
+
+	// VolatileCacheTTL is the longest the default policy caches endpoints that change with every block or after a reorg
+	VolatileCacheTTL = 5 * time.Second
+)
+
+// DefaultCacheableEndpoints are the read endpoints cached by DefaultCachePolicy
+// Endpoints that change state, such as send_transaction or push_tx, must never be added. Neither should lookups whose
+// results change as coins are spent, transactions are sent or plots are added, such as get_coin_records_by_puzzle_hash
+// or get_wallet_balance, since a cached response would hide the change for the whole TTL
+var DefaultCacheableEndpoints = []rpcinterface.Endpoint{
+	// Shared
+	"get_network_info",
+	"get_routes",
+	"get_version",
+
+	// Full node
+	"get_additions_and_removals",
+	"get_block",
+	"get_block_count_metrics",
+	"get_block_record",
+	"get_block_record_by_height",
+	"get_block_records",
+	"get_block_spends",
+	"get_block_spends_with_conditions",
+	"get_blockchain_state",
+	"get_blocks",
+	"get_fee_estimate",
+	"get_network_space",
+	"get_puzzle_and_solution",
+
+	// Crawler
+	"get_peer_counts",
+
+	// Wallet
+	"get_cat_list",
+	"get_height_info",
+	"get_sync_status",
+	"get_wallets",
+
+	// Data layer
+	"get_mirrors",
+	"get_owned_stores",
+}
+
+// volatileEndpoints are only cached briefly by the default policy, since they change with every block or as the
+// wallet or data layer changes. Lookups by height are included, since they return different blocks after a reorg
+var volatileEndpoints = map[rpcinterface.Endpoint]bool{
+	"get_block_count_metrics":    true,
+	"get_block_record_by_height": true,
+	"get_block_records":          true,
+	"get_blockchain_state":       true,
+	"get_blocks":                 true,
+	"get_fee_estimate":           true,
+	"get_height_info":            true,
+	"get_mirrors":                true,
+	"get_owned_stores":           true,
+	"get_peer_counts":            true,
+	"get_sync_status":            true,
+	"get_wallets":                true,
+}
+
+// CachePolicy decides which responses are cached and for how long
+type CachePolicy struct {
+	// TTLs is the allowlist of cacheable endpoints, and how long responses from each are cached
+	// Endpoints that are not listed are never cached
+	TTLs map[rpcinterface.Endpoint]time.Duration
+
+	// MaxEntries is the number of responses kept before the least recently used is evicted
+	MaxEntries int
+
+	// MaxBytes is the total size of the responses kept before the least recently used are evicted
+	MaxBytes int64
+
+	// MaxResponseBytes is the largest response that is cached. Larger responses are passed through without being
+	// buffered, so that endpoints such as get_blocks can't fill the cache with a few responses
+	MaxResponseBytes int64
+}
+
+// DefaultCachePolicy caches DefaultCacheableEndpoints for ttl
+// Endpoints that change with every block, such as get_blockchain_state, or that look blocks up by height, such as
+// get_blocks, are cached for at most VolatileCacheTTL. Only lookups by hash, such as get_block, use the full ttl
+func DefaultCachePolicy(ttl time.Duration) *CachePolicy {
+	policy := &CachePolicy{
+		TTLs:             map[rpcinterface.Endpoint]time.Duration{},
+		MaxEntries:       DefaultCacheMaxEntries,
+		MaxBytes:         DefaultCacheMaxBytes,
+		MaxResponseBytes: DefaultCacheMaxResponseBytes,
+	}
+	for _, endpoint := range DefaultCacheableEndpoints {
+		if volatileEndpoints[endpoint] {
+			policy.TTLs[endpoint] = min(ttl, VolatileCacheTTL)
+		} else {
+			policy.TTLs[endpoint] = ttl
+		}
+	}
+	return policy
+}
+
+// maxEntries returns MaxEntries, or the default if it isn't set
+func (p *CachePolicy) maxEntries() int {
+	if p.MaxEntries <= 0 {
+		return DefaultCacheMaxEntries
+	}
+	return p.MaxEntries
+}
+
+// maxBytes returns MaxBytes, or the default if it isn't set
+func (p *CachePolicy) maxBytes() int64 {
+	if p.MaxBytes <= 0 {
+		return DefaultCacheMaxBytes
+	}
+	return p.MaxBytes
+}
+
+// maxResponseBytes returns MaxResponseBytes, or the default if it isn't set. Never more than maxBytes
+func (p *CachePolicy) maxResponseBytes() int64 {
+	limit := p.MaxResponseBytes
+	if limit <= 0 {
+		limit = DefaultCacheMaxResponseBytes
+	}
+	return min(limit, p.maxBytes())
+}
+
+// ResponseCache is a bounded LRU cache of RPC responses, shared by the CachedTransports of a client
+type ResponseCache struct {
+	policy  *CachePolicy
+	metrics metrics.Recorder
+
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	size    int64
+	flights map[string]*flight
+
+	// generation is incremented by Invalidate, so responses to requests sent before the invalidation aren't cached
+	generation uint64
+}
+
+// cacheEntry is a cached response
+type cacheEntry struct {
+	key      string
+	endpoint rpcinterface.Endpoint
+	response []byte
+	expires  time.Time
+}
+
+// flight is an in progress request that identical concurrent requests wait on instead of sending their own
+// If the response couldn't be shared, such as when it is too large to cache, response and err are both nil, and the
+// waiting requests are sent on their own
+type flight struct {
+	done       chan struct{}
+	generation uint64
+	response   []byte
+	err        error
+}
+
+// NewResponseCache returns a new, empty cache using the policy
+func NewResponseCache(policy *CachePolicy) *ResponseCache {
+	return &ResponseCache{
+		policy:  policy,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+		flights: map[string]*flight{},
+	}
+}
+
+// SetMetrics sets the recorder that cache hits and misses are reported to
+func (c *ResponseCache) SetMetrics(recorder metrics.Recorder) {
+	c.metrics = recorder
+}
+
+// Invalidate removes the cached responses for the endpoints, or every cached response if no endpoints are provided
+func (c *ResponseCache) Invalidate(endpoints ...rpcinterface.Endpoint) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Requests already in flight may have been answered before the invalidation, so they aren't cached or shared with
+	// requests sent after it
+	c.generation++
+	c.flights = map[string]*flight{}
+
+	if len(endpoints) == 0 {
+		c.entries = map[string]*list.Element{}
+		c.lru.Init()
+		c.size = 0
+		return
+	}
+
+	remove := map[rpcinterface.Endpoint]bool{}
+	for _, endpoint := range endpoints {
+		remove[endpoint] = true
+	}
+	for _, element := range c.entries {
+		if remove[element.Value.(*cacheEntry).endpoint] {
+			c.remove(element)
+		}
+	}
+}
+
+// Len returns the number of cached responses, including any that have expired but not been evicted yet
+func (c *ResponseCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
+
+// get returns the cached response for the key, if it hasn't expired
+// Must be called with the lock held
+func (c *ResponseCache) get(key string) ([]byte, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(element)
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return entry.response, true
+}
+
+// set adds the response to the cache, evicting the least recently used responses if the cache is full
+// Must be called with the lock held
+func (c *ResponseCache) set(key string, endpoint rpcinterface.Endpoint, response []byte, ttl time.Duration) {
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{
+		key:      key,
+		endpoint: endpoint,
+		response: response,
+		expires:  time.Now().Add(ttl),
+	})
+	c.size += int64(len(response))
+
+	for c.lru.Len() > c.policy.maxEntries() || c.size > c.policy.maxBytes() {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove removes the entry from the cache
+// Must be called with the lock held
+func (c *ResponseCache) remove(element *list.Element) {
+	entry := element.Value.(*cacheEntry)
+	c.lru.Remove(element)
+	delete(c.entries, entry.key)
+	c.size -= int64(len(entry.response))
+}
+
 // CachedTransport is an http transport with cache on top
+// Only endpoints allowed by the cache policy are cached, and concurrent identical requests are only sent once
 type CachedTransport struct {
-	cache             *cache.Cache
+	cache             *ResponseCache
 	originalTransport http.RoundTripper
 }
 
-// NewCachedTransport returns a new transport wrapped in cache
+// NewCachedTransport returns a new transport wrapped in cache, using DefaultCachePolicy(expiration)
 func NewCachedTransport(expiration time.Duration, transport http.RoundTripper) *CachedTransport {
+	return NewCachedTransportWithCache(NewResponseCache(DefaultCachePolicy(expiration)), transport)
+}
+
+// NewCachedTransportWithCache returns a new transport wrapped in the provided cache
+// The same cache can be used by the transports for multiple services, so it can be invalidated in one place
+func NewCachedTransportWithCache(cache *ResponseCache, transport http.RoundTripper) *CachedTransport {
 	return &CachedTransport{
-		cache:             cache.New(expiration, expiration/2),
+		cache:             cache,
 		originalTransport: transport,
 	}
 }
 
+// SetMetrics sets the recorder that cache hits and misses are reported to
+func (c *CachedTransport) SetMetrics(recorder metrics.Recorder) {
+	c.cache.SetMetrics(recorder)
+}
+
+// Invalidate removes the cached responses for the endpoints, or every cached response if no endpoints are provided
+func (c *CachedTransport) Invalidate(endpoints ...rpcinterface.Endpoint) {
+	c.cache.Invalidate(endpoints...)
+}
+
 // key returns the cache key for the request
 func (c *CachedTransport) key(r *http.Request) string {
 	method := r.Method
 	url := r.URL.String()
 	body := ""
-	if r.Body != nil || r.Body != http.NoBody {
+	if r.Body != nil && r.Body != http.NoBody {
 		bodyBytes, _ := io.ReadAll(r.Body)
 		r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 		body = fmt.Sprintf("%x", sha256.Sum256(bodyBytes))
@@ -44,32 +327,111 @@ func (c *CachedTransport) key(r *http.Request) string {
 // RoundTrip executes a single HTTP transaction, returning
 // a Response for the provided Request.
 func (c *CachedTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	endpoint := rpcinterface.Endpoint(strings.TrimPrefix(r.URL.Path, "/"))
+	ttl, cacheable := c.cache.policy.TTLs[endpoint]
+	if !cacheable || ttl <= 0 {
+		return c.originalTransport.RoundTrip(r)
+	}
+
 	// MUST get this now, or else the body will be read and no longer available at the end
 	cacheKey := c.key(r)
 
+	c.cache.lock.Lock()
 	// If the response is cached, we can just respond with the cached version
-	if cached, found := c.cache.Get(cacheKey); found {
-		cachedBytes := cached.([]byte)
-		return c.cachedResponse(cachedBytes, r)
+	if cached, found := c.cache.get(cacheKey); found {
+		c.cache.lock.Unlock()
+		c.observe(true)
+		return c.cachedResponse(cached, r)
 	}
+	c.observe(false)
+
+	// If an identical request is already in flight, wait for it instead of sending another
+	if inFlight, ok := c.cache.flights[cacheKey]; ok {
+		c.cache.lock.Unlock()
+		select {
+		case <-inFlight.done:
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+		// The other request was cancelled, but this one wasn't, or the response couldn't be shared, so send it
+		if inFlight.err != nil && isContextError(inFlight.err) && r.Context().Err() == nil {
+			return c.originalTransport.RoundTrip(r)
+		}
+		if inFlight.err == nil && inFlight.response == nil {
+			return c.originalTransport.RoundTrip(r)
+		}
+		if inFlight.err != nil {
+			return nil, inFlight.err
+		}
+		return c.cachedResponse(inFlight.response, r)
+	}
+
+	current := &flight{done: make(chan struct{}), generation: c.cache.generation}
+	c.cache.flights[cacheKey] = current
+	c.cache.lock.Unlock()
 
 	resp, err := c.originalTransport.RoundTrip(r)
+	var buf []byte
+	if err == nil {
+		// Grab the body and stick it in the cache, unless it is too large to buffer
+		buf, err = c.bufferResponse(resp)
+	}
+
+	// The body is parsed before taking the lock, so a large response doesn't block every other cache lookup
+	successful := err == nil && buf != nil && resp.StatusCode == http.StatusOK && isSuccessfulRPC(resp)
+
+	c.cache.lock.Lock()
+	if c.cache.flights[cacheKey] == current {
+		delete(c.cache.flights, cacheKey)
+	}
+	if successful && current.generation == c.cache.generation {
+		c.cache.set(cacheKey, endpoint, buf, ttl)
+	}
+	c.cache.lock.Unlock()
+
+	current.response = buf
+	current.err = err
+	close(current.done)
 
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
 
-	// Grab the body and stick it in the cache
-	buf, err := httputil.DumpResponse(resp, true)
+// bufferResponse reads the response into memory so it can be cached, returning the dumped response
+// Returns nil without an error if the response is too large to cache, in which case the body is left to be streamed
+func (c *CachedTransport) bufferResponse(resp *http.Response) ([]byte, error) {
+	limit := c.cache.policy.maxResponseBytes()
+	if resp.StatusCode != http.StatusOK || resp.ContentLength > limit {
+		return nil, nil
+	}
 
+	head, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
 	if err != nil {
+		_ = resp.Body.Close()
 		return nil, err
 	}
+	if int64(len(head)) > limit {
+		resp.Body = &prefixedReadCloser{Reader: io.MultiReader(bytes.NewReader(head), resp.Body), Closer: resp.Body}
+		return nil, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(head))
+	return httputil.DumpResponse(resp, true)
+}
 
-	// Add the response bytes to cache
-	c.cache.Set(cacheKey, buf, cache.DefaultExpiration)
+// prefixedReadCloser is a response body where part of the body has already been read into memory
+type prefixedReadCloser struct {
+	io.Reader
+	io.Closer
+}
 
-	return resp, nil
+// observe reports a cache hit or miss to metrics, if set
+func (c *CachedTransport) observe(hit bool) {
+	if c.cache.metrics != nil {
+		c.cache.metrics.ObserveCache(hit)
+	}
 }
 
 // cachedResponse returns a response built from cached data
@@ -77,3 +439,25 @@ func (c *CachedTransport) cachedResponse(b []byte, r *http.Request) (*http.Respo
 	buf := bytes.NewBuffer(b)
 	return http.ReadResponse(bufio.NewReader(buf), r)
 }
+
+// isSuccessfulRPC returns true if the response reports success, so RPC errors such as "block not found" aren't cached
+// The response body is restored so it can still be read
+func isSuccessfulRPC(resp *http.Response) bool {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	result := &rpcinterface.Response{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return false
+	}
+	return result.IsSuccessful()
+}
+
+// isContextError returns true if the error is from a cancelled request
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
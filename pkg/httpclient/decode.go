@@ -0,0 +1,156 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+// ErrResponseTooLarge is returned when a response body is larger than the maximum response size
+var ErrResponseTooLarge = errors.New("response exceeds maximum size")
+
+// DecodeOptions configures how response bodies are read
+type DecodeOptions struct {
+	// MaxResponseSize is the largest response body, in bytes, that will be read. Unlimited if 0
+	MaxResponseSize int64
+
+	// Streaming decodes the response as it is read, instead of reading the entire body into memory first
+	// The body of the returned http.Response is empty when streaming, since it has already been consumed
+	Streaming bool
+}
+
+// DecodeResponse reads the response body into v, or copies it to v if v is an io.Writer
+// When not streaming, resp.Body is replaced with the buffered body so it can still be read by the caller
+func DecodeResponse(resp *http.Response, endpoint rpcinterface.Endpoint, v rpcinterface.IResponse, opts DecodeOptions) error {
+	if opts.MaxResponseSize > 0 && resp.ContentLength > opts.MaxResponseSize {
+		_ = resp.Body.Close()
+		resp.Body = http.NoBody
+		return tooLargeError(endpoint, opts.MaxResponseSize)
+	}
+
+	var body io.Reader = resp.Body
+	if opts.MaxResponseSize > 0 {
+		body = &limitedReader{reader: resp.Body, remaining: opts.MaxResponseSize, endpoint: endpoint, limit: opts.MaxResponseSize}
+	}
+
+	if opts.Streaming {
+		err := decode(body, v)
+		if err == nil {
+			// Drain anything after the JSON value, so the connection can be reused
+			_, err = io.Copy(io.Discard, body)
+		}
+		closeErr := resp.Body.Close()
+		resp.Body = http.NoBody
+		if err != nil {
+			return err
+		}
+		return closeErr
+	}
+
+	bodyBytes, err := io.ReadAll(body)
+	_ = resp.Body.Close()
+	if err != nil {
+		resp.Body = http.NoBody
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+
+	if v == nil {
+		return nil
+	}
+	if w, ok := v.(io.Writer); ok {
+		_, err = w.Write(bodyBytes)
+		return err
+	}
+	return json.Unmarshal(bodyBytes, v)
+}
+
+// decode reads the body into v as it is received
+func decode(body io.Reader, v rpcinterface.IResponse) error {
+	if v == nil {
+		return nil
+	}
+	if w, ok := v.(io.Writer); ok {
+		_, err := io.Copy(w, body)
+		return err
+	}
+	return json.NewDecoder(body).Decode(v)
+}
+
+// SizeLimitTransport is an http transport that fails responses larger than the maximum response size
+// It is added below the fixture and cache transports, so they never read more than the limit into memory
+type SizeLimitTransport struct {
+	maxBytes          int64
+	originalTransport http.RoundTripper
+}
+
+// NewSizeLimitTransport returns a new transport that fails responses larger than maxBytes with ErrResponseTooLarge
+func NewSizeLimitTransport(maxBytes int64, transport http.RoundTripper) *SizeLimitTransport {
+	return &SizeLimitTransport{
+		maxBytes:          maxBytes,
+		originalTransport: transport,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction, limiting the size of the response body, returning
+// a Response for the provided Request.
+func (t *SizeLimitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.originalTransport.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := rpcinterface.Endpoint(strings.TrimPrefix(r.URL.Path, "/"))
+	if resp.ContentLength > t.maxBytes {
+		_ = resp.Body.Close()
+		return nil, tooLargeError(endpoint, t.maxBytes)
+	}
+	resp.Body = &limitedReadCloser{
+		Reader: &limitedReader{reader: resp.Body, remaining: t.maxBytes, endpoint: endpoint, limit: t.maxBytes},
+		Closer: resp.Body,
+	}
+	return resp, nil
+}
+
+// limitedReadCloser is a response body that fails once more than the limit has been read
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// limitedReader returns ErrResponseTooLarge once more than the limit has been read
+// Unlike io.LimitReader, going over the limit is an error instead of silently truncating the response
+type limitedReader struct {
+	reader    io.Reader
+	remaining int64
+	endpoint  rpcinterface.Endpoint
+	limit     int64
+}
+
+// Read satisfies the io.Reader interface
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, tooLargeError(l.endpoint, l.limit)
+	}
+	// Read one byte past the limit, to tell a body of exactly the limit from one that is too large
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.reader.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n - 1, tooLargeError(l.endpoint, l.limit)
+	}
+	return n, err
+}
+
+// tooLargeError returns ErrResponseTooLarge with the endpoint and limit
+func tooLargeError(endpoint rpcinterface.Endpoint, limit int64) error {
+	return fmt.Errorf("%w: %s response is larger than %d bytes", ErrResponseTooLarge, endpoint, limit)
+}
@@ -0,0 +1,284 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+// DefaultHealthCheckInterval is how often full node health is re-checked when failover nodes are configured
+const DefaultHealthCheckInterval = 30 * time.Second
+
+// HealthCheckTimeout is the longest a single full node health check may take
+const HealthCheckTimeout = 5 * time.Second
+
+// NodeStatus is the last known health of a full node used for failover
+type NodeStatus struct {
+	URL         *url.URL
+	Healthy     bool
+	Synced      bool
+	PeakHeight  uint32
+	LastChecked time.Time
+	LastError   error
+
+	// configured is the URL as it was passed to SetFullNodes, before the default port is added
+	configured *url.URL
+}
+
+// SetFullNodes configures a list of full nodes to use instead of the base URL
+// Nodes are health checked with get_blockchain_state, and requests are sent to the healthy, synced node with the
+// highest peak. If a node can't be reached, the request fails over to the next best node
+// Nodes without a port use the full node RPC port, which is resolved when requests are made so that options that change
+// the port may be applied in any order
+func (c *HTTPClient) SetFullNodes(nodes []*url.URL) {
+	c.fullNodesLock.Lock()
+	defer c.fullNodesLock.Unlock()
+
+	c.fullNodes = nil
+	for _, node := range nodes {
+		u := *node
+		if u.Scheme == "" {
+			u.Scheme = "https"
+		}
+		c.fullNodes = append(c.fullNodes, &NodeStatus{URL: &u, Healthy: true, configured: &u})
+	}
+	c.fullNodesChecked = time.Time{}
+}
+
+// resolveFullNodeURLs adds the full node port to any node URL without one
+// Must be called with fullNodesLock held
+func (c *HTTPClient) resolveFullNodeURLs() {
+	for _, node := range c.fullNodes {
+		u := *node.configured
+		if u.Port() == "" {
+			u.Host = fmt.Sprintf("%s:%d", u.Host, c.nodePort)
+		}
+		node.URL = &u
+	}
+}
+
+// SetHealthCheckInterval sets how often the failover full nodes are health checked
+func (c *HTTPClient) SetHealthCheckInterval(interval time.Duration) {
+	c.healthCheckInterval = interval
+}
+
+// FullNodeStatuses returns the last known status of all configured failover full nodes
+func (c *HTTPClient) FullNodeStatuses() []NodeStatus {
+	c.fullNodesLock.Lock()
+	defer c.fullNodesLock.Unlock()
+
+	c.resolveFullNodeURLs()
+	statuses := make([]NodeStatus, 0, len(c.fullNodes))
+	for _, node := range c.fullNodes {
+		statuses = append(statuses, *node)
+	}
+	return statuses
+}
+
+// failoverTransport sends full node requests to the best failover node, failing over to the next best node on
+// connection errors. It is added directly above the base transport, so a retry policy retries across all the nodes
+// instead of using up its attempts on a dead node
+type failoverTransport struct {
+	client            *HTTPClient
+	probe             *http.Client
+	originalTransport http.RoundTripper
+}
+
+// newFailoverTransport returns a failover transport for the client's full nodes
+// Health checks are sent with probe, which skips the retry, cache and fixture transports
+func newFailoverTransport(client *HTTPClient, probe *http.Client, transport http.RoundTripper) *failoverTransport {
+	return &failoverTransport{
+		client:            client,
+		probe:             probe,
+		originalTransport: transport,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction against the best full node, returning
+// a Response for the provided Request.
+func (t *failoverTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if !t.client.hasFullNodes() {
+		return t.originalTransport.RoundTrip(r)
+	}
+	endpoint := rpcinterface.Endpoint(strings.TrimPrefix(r.URL.Path, "/"))
+
+	// Body has to be buffered so that it can be sent to each node
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := r.Context()
+	nodes := t.client.rankedFullNodes(ctx, t.probe)
+
+	var lastErr error
+	for _, node := range nodes {
+		resp, err := t.originalTransport.RoundTrip(requestForNode(r, node.URL, body))
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		t.client.markFullNodeUnhealthy(node, err)
+
+		// Anything other than a failed dial might have reached the node, so only read-only calls can safely be resent
+		if ctx.Err() != nil || (!isDialError(err) && !endpoint.IsReadOnly()) {
+			break
+		}
+		t.client.logger.Warn("Full node request failed, failing over to the next node", "node", node.URL.Host, "endpoint", endpoint, "error", err.Error())
+	}
+
+	return nil, lastErr
+}
+
+// rankedFullNodes returns the failover nodes in the order they should be tried, refreshing health if it is stale
+// Healthy synced nodes come first, ordered by peak height. Unhealthy nodes are still returned last as a final resort
+func (c *HTTPClient) rankedFullNodes(ctx context.Context, probe *http.Client) []*NodeStatus {
+	c.fullNodesLock.Lock()
+	c.resolveFullNodeURLs()
+	interval := c.healthCheckInterval
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+	stale := time.Since(c.fullNodesChecked) > interval
+	if stale {
+		c.fullNodesChecked = time.Now()
+	}
+	nodes := append([]*NodeStatus{}, c.fullNodes...)
+	c.fullNodesLock.Unlock()
+
+	if stale {
+		c.checkFullNodes(ctx, probe, nodes)
+	}
+
+	c.fullNodesLock.Lock()
+	defer c.fullNodesLock.Unlock()
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		if a.Healthy != b.Healthy {
+			return a.Healthy
+		}
+		if a.Synced != b.Synced {
+			return a.Synced
+		}
+		return a.PeakHeight > b.PeakHeight
+	})
+
+	return nodes
+}
+
+// checkFullNodes health checks all nodes concurrently
+// If ctx is cancelled before the checks finish, the results are discarded and the nodes are checked again on the next
+// request, since a cancelled check says nothing about the health of the node
+func (c *HTTPClient) checkFullNodes(ctx context.Context, probe *http.Client, nodes []*NodeStatus) {
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node *NodeStatus) {
+			defer wg.Done()
+			state, err := checkFullNode(ctx, probe, node.URL)
+
+			c.fullNodesLock.Lock()
+			defer c.fullNodesLock.Unlock()
+			if ctx.Err() != nil {
+				c.fullNodesChecked = time.Time{}
+				return
+			}
+			node.LastChecked = time.Now()
+			node.LastError = err
+			node.Healthy = err == nil
+			if err != nil {
+				node.Synced = false
+				return
+			}
+			node.Synced = state.Sync.Synced
+			node.PeakHeight = 0
+			if peak, ok := state.Peak.Get(); ok {
+				node.PeakHeight = peak.Height
+			}
+		}(node)
+	}
+	wg.Wait()
+}
+
+// checkFullNode calls get_blockchain_state on a single node, giving up after HealthCheckTimeout
+func checkFullNode(ctx context.Context, client *http.Client, node *url.URL) (*types.BlockchainState, error) {
+	ctx, cancel := context.WithTimeout(ctx, HealthCheckTimeout)
+	defer cancel()
+
+	u := *node
+	u.Path = "/get_blockchain_state"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader([]byte(`{}`)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	state := &struct {
+		rpcinterface.Response
+		BlockchainState types.BlockchainState `json:"blockchain_state"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(state); err != nil {
+		return nil, err
+	}
+	if !state.IsSuccessful() {
+		return nil, fmt.Errorf("get_blockchain_state failed: %s", state.GetRPCError())
+	}
+
+	return &state.BlockchainState, nil
+}
+
+// markFullNodeUnhealthy marks the node as unhealthy until the next health check
+func (c *HTTPClient) markFullNodeUnhealthy(node *NodeStatus, err error) {
+	c.fullNodesLock.Lock()
+	defer c.fullNodesLock.Unlock()
+	node.Healthy = false
+	node.LastError = err
+}
+
+// requestForNode clones the request, pointing it at the node
+func requestForNode(r *http.Request, node *url.URL, body []byte) *http.Request {
+	req := r.Clone(r.Context())
+	req.URL.Scheme = node.Scheme
+	req.URL.Host = node.Host
+	req.Host = ""
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return req
+}
+
+// isDialError returns true if the error happened while connecting, so the request never reached the server
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// hasFullNodes returns true if failover full nodes are configured
+func (c *HTTPClient) hasFullNodes() bool {
+	c.fullNodesLock.Lock()
+	defer c.fullNodesLock.Unlock()
+	return len(c.fullNodes) > 0
+}
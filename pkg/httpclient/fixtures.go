@@ -0,0 +1,260 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+// ErrNoFixture is returned when replaying and there is no recorded response for a request
+var ErrNoFixture = errors.New("no recorded fixture for request")
+
+// FixtureMode is how fixtures are used by the client
+type FixtureMode uint8
+
+const (
+	// FixtureModeOff sends requests to the server without recording them
+	FixtureModeOff FixtureMode = iota
+
+	// FixtureModeRecord sends requests to the server and saves the request and response in the fixture files
+	FixtureModeRecord
+
+	// FixtureModeReplay responds with the recorded responses without connecting to the server
+	FixtureModeReplay
+)
+
+// Fixture is a recorded request and response
+// Fixtures are stored per service and endpoint in <dir>/<service>/<endpoint>.json as a list of fixtures
+type Fixture struct {
+	Request    json.RawMessage `json:"request"`
+	StatusCode int             `json:"status_code"`
+	Response   json.RawMessage `json:"response,omitempty"`
+
+	// ResponseText is used instead of Response when the response body isn't JSON, such as from a proxy error
+	ResponseText string `json:"response_text,omitempty"`
+}
+
+// FixtureTransport returns the transport for the fixture mode
+// When replaying, the base transport is never used since responses come from the fixture files
+func FixtureTransport(mode FixtureMode, dir string, service rpcinterface.ServiceType, transport http.RoundTripper) http.RoundTripper {
+	switch mode {
+	case FixtureModeRecord:
+		return NewRecordTransport(dir, service, transport)
+	case FixtureModeReplay:
+		return NewReplayTransport(dir, service)
+	}
+	return transport
+}
+
+// fixtureStore reads and writes the fixture files for a service
+type fixtureStore struct {
+	dir     string
+	service rpcinterface.ServiceType
+
+	// lock guards writing fixture files, so concurrent requests don't lose recordings
+	lock sync.Mutex
+}
+
+// path returns the fixture file for the endpoint
+func (s *fixtureStore) path(endpoint rpcinterface.Endpoint) string {
+	return filepath.Join(s.dir, s.service.String(), fmt.Sprintf("%s.json", endpoint))
+}
+
+// load returns all fixtures recorded for the endpoint
+func (s *fixtureStore) load(endpoint rpcinterface.Endpoint) ([]Fixture, error) {
+	b, err := os.ReadFile(s.path(endpoint))
+	if err != nil {
+		return nil, err
+	}
+	var fixtures []Fixture
+	if err := json.Unmarshal(b, &fixtures); err != nil {
+		return nil, fmt.Errorf("error parsing fixture file %s: %w", s.path(endpoint), err)
+	}
+	// Files are indented when saved, so requests are normalized again to compare them
+	for i := range fixtures {
+		fixtures[i].Request, err = compactJSON(fixtures[i].Request)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing fixture file %s: %w", s.path(endpoint), err)
+		}
+	}
+	return fixtures, nil
+}
+
+// save records the fixture, replacing any existing fixture for the same request
+func (s *fixtureStore) save(endpoint rpcinterface.Endpoint, fixture Fixture) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	fixtures, err := s.load(endpoint)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	replaced := false
+	for i := range fixtures {
+		if bytes.Equal(fixtures[i].Request, fixture.Request) {
+			fixtures[i] = fixture
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		fixtures = append(fixtures, fixture)
+	}
+
+	b, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path(endpoint)), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(endpoint), append(b, '\n'), 0644)
+}
+
+// RecordTransport sends requests with the wrapped transport and records every request and response to fixture files
+type RecordTransport struct {
+	store             *fixtureStore
+	originalTransport http.RoundTripper
+}
+
+// NewRecordTransport returns a transport that records fixtures for the service to dir
+func NewRecordTransport(dir string, service rpcinterface.ServiceType, transport http.RoundTripper) *RecordTransport {
+	return &RecordTransport{
+		store:             &fixtureStore{dir: dir, service: service},
+		originalTransport: transport,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction, returning
+// a Response for the provided Request.
+func (t *RecordTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	endpoint, request, err := fixtureRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.originalTransport.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	fixture := Fixture{
+		Request:    request,
+		StatusCode: resp.StatusCode,
+	}
+	if compacted, err := compactJSON(body); err == nil {
+		fixture.Response = compacted
+	} else {
+		fixture.ResponseText = string(body)
+	}
+
+	if err := t.store.save(endpoint, fixture); err != nil {
+		return nil, fmt.Errorf("error recording fixture for %s/%s: %w", t.store.service, endpoint, err)
+	}
+
+	return resp, nil
+}
+
+// ReplayTransport responds to requests with fixtures recorded by RecordTransport, without connecting to the server
+// Requests are matched on the service, endpoint and JSON payload. Requests that were not recorded fail with ErrNoFixture
+type ReplayTransport struct {
+	store *fixtureStore
+}
+
+// NewReplayTransport returns a transport that replays the fixtures for the service from dir
+func NewReplayTransport(dir string, service rpcinterface.ServiceType) *ReplayTransport {
+	return &ReplayTransport{
+		store: &fixtureStore{dir: dir, service: service},
+	}
+}
+
+// RoundTrip executes a single HTTP transaction, returning
+// a Response for the provided Request.
+func (t *ReplayTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	endpoint, request, err := fixtureRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures, err := t.store.load(endpoint)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, fixture := range fixtures {
+		if !bytes.Equal(fixture.Request, request) {
+			continue
+		}
+
+		body := []byte(fixture.Response)
+		if fixture.Response == nil {
+			body = []byte(fixture.ResponseText)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", fixture.StatusCode, http.StatusText(fixture.StatusCode)),
+			StatusCode:    fixture.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       r,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s/%s with request %s (%d recorded in %s)", ErrNoFixture, t.store.service, endpoint, request, len(fixtures), t.store.path(endpoint))
+}
+
+// fixtureRequest returns the endpoint and the normalized JSON payload of the request
+// The request body is restored so it can still be sent
+func fixtureRequest(r *http.Request) (rpcinterface.Endpoint, json.RawMessage, error) {
+	endpoint := rpcinterface.Endpoint(strings.TrimPrefix(r.URL.Path, "/"))
+
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		if err != nil {
+			return endpoint, nil, err
+		}
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if len(body) == 0 {
+		body = []byte(`{}`)
+	}
+
+	request, err := compactJSON(body)
+	if err != nil {
+		return endpoint, nil, fmt.Errorf("request to %s is not valid JSON: %w", endpoint, err)
+	}
+	return endpoint, request, nil
+}
+
+// compactJSON normalizes JSON so equivalent payloads compare equal, with object keys sorted
+func compactJSON(b []byte) (json.RawMessage, error) {
+	var v any
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
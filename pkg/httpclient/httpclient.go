@@ -5,16 +5,19 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
 	"github.com/google/uuid"
 
 	"github.com/chia-network/go-chia-libs/pkg/config"
+	"github.com/chia-network/go-chia-libs/pkg/metrics"
 	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
 )
 
@@ -24,12 +27,50 @@ type HTTPClient struct {
 	baseURL *url.URL
 	logger  *slog.Logger
 
+	// serviceURLs override the base URL for individual services
+	serviceURLs map[rpcinterface.ServiceType]*url.URL
+
+	// interceptors wrap every call to Do
+	interceptors []rpcinterface.Interceptor
+
 	// If set > 0, will configure http requests with a cache
 	cacheValidTime time.Duration
 
+	// If set, responses are cached according to the policy instead of the default policy for cacheValidTime
+	cachePolicy *CachePolicy
+
+	// responseCache is shared by the http clients for all services, so it can be invalidated in one place
+	responseCache *ResponseCache
+
+	// If set, failed requests to read-only endpoints are retried according to the policy
+	retryPolicy *RetryPolicy
+
+	// If set, requests are recorded to or replayed from fixture files in fixtureDir
+	fixtureMode FixtureMode
+	fixtureDir  string
+
+	// If set, cache hits and misses are reported to metrics
+	metrics metrics.Recorder
+
+	// decodeOptions limits the size of responses, and whether they are decoded as they are read
+	decodeOptions DecodeOptions
+
 	// Request timeout
 	Timeout time.Duration
 
+	// If set, connections are opened with dialContext and requests are sent with transport
+	dialContext rpcinterface.DialContextFunc
+	transport   http.RoundTripper
+
+	// If set, full node requests are sent to the best of these nodes instead of the base URL
+	fullNodes           []*NodeStatus
+	fullNodesChecked    time.Time
+	fullNodesLock       sync.Mutex
+	healthCheckInterval time.Duration
+
+	// clientLock guards lazily creating the http clients for each service, so requests can be made concurrently
+	clientLock sync.Mutex
+
 	nodePort    uint16
 	nodeKeyPair *tls.Certificate
 	nodeClient  *http.Client
@@ -104,6 +145,71 @@ func (c *HTTPClient) SetBaseURL(url *url.URL) error {
 	return nil
 }
 
+// SetServiceURL sets the scheme and host for requests to a single service, overriding the base URL
+// If the URL includes a port, it overrides the port from the config for the service
+func (c *HTTPClient) SetServiceURL(service rpcinterface.ServiceType, serviceURL *url.URL) error {
+	if !isKnownService(service) {
+		return fmt.Errorf("unknown service")
+	}
+	u := *serviceURL
+	if port := u.Port(); port != "" {
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid port for %s: %w", service, err)
+		}
+		c.setPortForService(service, uint16(p))
+		u.Host = u.Hostname()
+	}
+
+	if c.serviceURLs == nil {
+		c.serviceURLs = map[rpcinterface.ServiceType]*url.URL{}
+	}
+	c.serviceURLs[service] = &u
+
+	return nil
+}
+
+// SetServiceKeyPair sets the certificate and key used to connect to a single service
+// instead of loading the private key pair for the service from the local config
+func (c *HTTPClient) SetServiceKeyPair(service rpcinterface.ServiceType, keyPair *tls.Certificate) error {
+	c.clientLock.Lock()
+	defer c.clientLock.Unlock()
+
+	switch service {
+	case rpcinterface.ServiceFullNode:
+		c.nodeKeyPair, c.nodeClient = keyPair, nil
+	case rpcinterface.ServiceFarmer:
+		c.farmerKeyPair, c.farmerClient = keyPair, nil
+	case rpcinterface.ServiceHarvester:
+		c.harvesterKeyPair, c.harvesterClient = keyPair, nil
+	case rpcinterface.ServiceWallet:
+		c.walletKeyPair, c.walletClient = keyPair, nil
+	case rpcinterface.ServiceCrawler:
+		c.crawlerKeyPair, c.crawlerClient = keyPair, nil
+	case rpcinterface.ServiceDataLayer:
+		c.datalayerKeyPair, c.datalayerClient = keyPair, nil
+	case rpcinterface.ServiceTimelord:
+		c.timelordKeyPair, c.timelordClient = keyPair, nil
+	default:
+		return fmt.Errorf("unknown service")
+	}
+
+	return nil
+}
+
+// LoadServiceKeyPair loads the private key pair for a service from a chia ssl directory
+// The directory uses the same layout as CHIA_ROOT/config/ssl, so the certs are read from
+// <sslDir>/<service>/private_<service>.crt and .key, such as a copy of the ssl directory from a remote machine
+func LoadServiceKeyPair(sslDir string, service rpcinterface.ServiceType) (*tls.Certificate, error) {
+	name := service.String()
+	base := filepath.Join(sslDir, name, fmt.Sprintf("private_%s", name))
+	pair, err := tls.LoadX509KeyPair(base+".crt", base+".key")
+	if err != nil {
+		return nil, fmt.Errorf("error loading %s key pair from %s: %w", name, sslDir, err)
+	}
+	return &pair, nil
+}
+
 // SetLogHandler sets a slog compatible log handler
 func (c *HTTPClient) SetLogHandler(handler slog.Handler) {
 	c.logger = slog.New(handler)
@@ -114,6 +220,66 @@ func (c *HTTPClient) SetCacheValidTime(validTime time.Duration) {
 	c.cacheValidTime = validTime
 }
 
+// SetCachePolicy sets which endpoints are cached, and for how long
+func (c *HTTPClient) SetCachePolicy(policy *CachePolicy) {
+	c.cachePolicy = policy
+}
+
+// InvalidateCache removes the cached responses for the endpoints, or every cached response if no endpoints are provided
+func (c *HTTPClient) InvalidateCache(endpoints ...rpcinterface.Endpoint) {
+	c.clientLock.Lock()
+	defer c.clientLock.Unlock()
+	if c.responseCache != nil {
+		c.responseCache.Invalidate(endpoints...)
+	}
+}
+
+// SetMaxResponseSize sets the largest response body, in bytes, that will be read. Unlimited if 0
+// Larger responses fail with ErrResponseTooLarge instead of being read into memory
+// Must be set before the first request is made
+func (c *HTTPClient) SetMaxResponseSize(maxBytes int64) {
+	c.decodeOptions.MaxResponseSize = maxBytes
+}
+
+// SetStreamingDecode decodes responses as they are read, instead of reading the entire body into memory first
+// The body of the http.Response returned by Do is empty when streaming
+func (c *HTTPClient) SetStreamingDecode(streaming bool) {
+	c.decodeOptions.Streaming = streaming
+}
+
+// SetRetryPolicy sets the policy used to retry failed requests
+func (c *HTTPClient) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// SetDialer sets the function used to open connections, such as to connect through a unix socket
+// Must be set before the first request is made
+func (c *HTTPClient) SetDialer(dial rpcinterface.DialContextFunc) {
+	c.dialContext = dial
+}
+
+// SetTransport sets the base transport requests are sent with. Retry and cache transports still wrap it when enabled
+// Must be set before the first request is made
+func (c *HTTPClient) SetTransport(transport http.RoundTripper) {
+	c.transport = transport
+}
+
+// SetFixtures records requests to, or replays responses from, the fixture files in dir
+func (c *HTTPClient) SetFixtures(mode FixtureMode, dir string) {
+	c.fixtureMode = mode
+	c.fixtureDir = dir
+}
+
+// SetMetrics sets the recorder that cache hits and misses are reported to
+func (c *HTTPClient) SetMetrics(recorder metrics.Recorder) {
+	c.metrics = recorder
+}
+
+// AddInterceptor adds an interceptor that wraps every request made with Do
+func (c *HTTPClient) AddInterceptor(interceptor rpcinterface.Interceptor) {
+	c.interceptors = append(c.interceptors, interceptor)
+}
+
 // NewRequest creates an RPC request for the specified service
 func (c *HTTPClient) NewRequest(service rpcinterface.ServiceType, rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
 	// Always POST
@@ -121,6 +287,9 @@ func (c *HTTPClient) NewRequest(service rpcinterface.ServiceType, rpcEndpoint rp
 	method := http.MethodPost
 
 	u := *c.baseURL
+	if serviceURL, ok := c.serviceURLs[service]; ok {
+		u = *serviceURL
+	}
 
 	u.Host = fmt.Sprintf("%s:%d", u.Host, c.portForService(service))
 
@@ -165,13 +334,21 @@ func (c *HTTPClient) NewRequest(service rpcinterface.ServiceType, rpcEndpoint rp
 	}
 
 	return &rpcinterface.Request{
-		Service: service,
-		Request: req,
+		Service:  service,
+		Endpoint: rpcEndpoint,
+		Data:     opt,
+		Request:  req,
 	}, nil
 }
 
 // Do sends an RPC request and returns the RPC response.
+// The request is bound to req.Context(), so cancellation and deadlines abort the call
 func (c *HTTPClient) Do(req *rpcinterface.Request, v rpcinterface.IResponse) (*http.Response, error) {
+	return rpcinterface.ChainInterceptors(c.interceptors, c.do)(req, v)
+}
+
+// do sends the request without any interceptors applied
+func (c *HTTPClient) do(req *rpcinterface.Request, v rpcinterface.IResponse) (*http.Response, error) {
 	client, err := c.httpClientForService(req.Service)
 	if err != nil {
 		return nil, err
@@ -182,23 +359,7 @@ func (c *HTTPClient) Do(req *rpcinterface.Request, v rpcinterface.IResponse) (*h
 		return nil, err
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	err = resp.Body.Close()
-	if err != nil {
-		return resp, err
-	}
-	resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, bytes.NewReader(bodyBytes))
-		} else {
-			err = json.Unmarshal(bodyBytes, v)
-		}
-	}
+	err = DecodeResponse(resp, req.Endpoint, v, c.decodeOptions)
 
 	return resp, err
 }
@@ -209,6 +370,14 @@ func (c *HTTPClient) Close() error {
 }
 
 func (c *HTTPClient) generateHTTPClientForService(service rpcinterface.ServiceType) (*http.Client, error) {
+	// Replaying never connects to the service, so the certs don't need to exist
+	if c.fixtureMode == FixtureModeReplay {
+		return &http.Client{
+			Transport: NewReplayTransport(c.fixtureDir, service),
+			Timeout:   c.Timeout,
+		}, nil
+	}
+
 	var (
 		keyPair *tls.Certificate
 		err     error
@@ -282,15 +451,34 @@ func (c *HTTPClient) generateHTTPClientForService(service rpcinterface.ServiceTy
 
 	var transport http.RoundTripper
 
-	transport = &http.Transport{
-		TLSClientConfig: &tls.Config{
-			Certificates:       []tls.Certificate{*keyPair},
-			InsecureSkipVerify: true, // Cert is apparently for chia.net - can't validate until it matches hostname
-		},
+	transport = BaseTransport(c.transport, c.dialContext, &tls.Config{
+		Certificates:       []tls.Certificate{*keyPair},
+		InsecureSkipVerify: true, // Cert is apparently for chia.net - can't validate until it matches hostname
+	})
+
+	// Failover is below the retry transport, so each attempt can go to a different node. Health checks use the base
+	// transport, so probes are never retried, cached or recorded
+	if service == rpcinterface.ServiceFullNode {
+		probe := &http.Client{
+			Transport: transport,
+			Timeout:   c.Timeout,
+		}
+		transport = newFailoverTransport(c, probe, transport)
+	}
+
+	if c.retryPolicy != nil {
+		transport = NewRetryTransport(c.retryPolicy, transport)
 	}
 
-	if c.cacheValidTime > 0 {
-		transport = NewCachedTransport(c.cacheValidTime, transport)
+	// Added below the fixture and cache transports, so oversized responses are never recorded or cached
+	if c.decodeOptions.MaxResponseSize > 0 {
+		transport = NewSizeLimitTransport(c.decodeOptions.MaxResponseSize, transport)
+	}
+
+	transport = FixtureTransport(c.fixtureMode, c.fixtureDir, service, transport)
+
+	if cache := c.sharedResponseCache(); cache != nil {
+		transport = NewCachedTransportWithCache(cache, transport)
 	}
 
 	client := &http.Client{
@@ -325,8 +513,58 @@ func (c *HTTPClient) portForService(service rpcinterface.ServiceType) uint16 {
 	return port
 }
 
+// setPortForService overrides the configured port for the service
+func (c *HTTPClient) setPortForService(service rpcinterface.ServiceType, port uint16) {
+	switch service {
+	case rpcinterface.ServiceFullNode:
+		c.nodePort = port
+	case rpcinterface.ServiceFarmer:
+		c.farmerPort = port
+	case rpcinterface.ServiceHarvester:
+		c.harvesterPort = port
+	case rpcinterface.ServiceWallet:
+		c.walletPort = port
+	case rpcinterface.ServiceCrawler:
+		c.crawlerPort = port
+	case rpcinterface.ServiceDataLayer:
+		c.datalayerPort = port
+	case rpcinterface.ServiceTimelord:
+		c.timelordPort = port
+	}
+}
+
+// isKnownService returns true for the services the HTTP client can connect to
+func isKnownService(service rpcinterface.ServiceType) bool {
+	switch service {
+	case rpcinterface.ServiceFullNode, rpcinterface.ServiceFarmer, rpcinterface.ServiceHarvester, rpcinterface.ServiceWallet,
+		rpcinterface.ServiceCrawler, rpcinterface.ServiceDataLayer, rpcinterface.ServiceTimelord:
+		return true
+	}
+	return false
+}
+
+// sharedResponseCache returns the response cache for all services, or nil if caching isn't enabled
+// Must be called with clientLock held
+func (c *HTTPClient) sharedResponseCache() *ResponseCache {
+	if c.responseCache == nil {
+		policy := c.cachePolicy
+		if policy == nil && c.cacheValidTime > 0 {
+			policy = DefaultCachePolicy(c.cacheValidTime)
+		}
+		if policy == nil {
+			return nil
+		}
+		c.responseCache = NewResponseCache(policy)
+		c.responseCache.SetMetrics(c.metrics)
+	}
+	return c.responseCache
+}
+
 // httpClientForService returns the proper http client to use with the service
 func (c *HTTPClient) httpClientForService(service rpcinterface.ServiceType) (*http.Client, error) {
+	c.clientLock.Lock()
+	defer c.clientLock.Unlock()
+
 	var (
 		client *http.Client
 		err    error
@@ -0,0 +1,179 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+// RetryPolicy configures how failed requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first request
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait between any two attempts
+	// When the server asks for a longer wait with Retry-After, the response is returned instead of retrying
+	MaxBackoff time.Duration
+
+	// Multiplier is applied to the backoff after every attempt
+	Multiplier float64
+
+	// Jitter is the fraction (0-1) of each backoff that is randomized to avoid retrying in lockstep
+	Jitter float64
+
+	// RetryableStatusCodes are HTTP status codes that are retried, in addition to connection errors
+	RetryableStatusCodes []int
+
+	// ShouldRetryEndpoint decides if requests to an endpoint may be retried
+	// Defaults to Endpoint.IsReadOnly, so mutating calls like send_transaction are never sent twice
+	ShouldRetryEndpoint func(endpoint rpcinterface.Endpoint) bool
+}
+
+// DefaultRetryPolicy returns a retry policy suitable for riding out chia service restarts
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          5,
+		InitialBackoff:       250 * time.Millisecond,
+		MaxBackoff:           5 * time.Second,
+		Multiplier:           2,
+		Jitter:               0.2,
+		RetryableStatusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	}
+}
+
+// Backoff returns the wait before the retry following the given attempt (0 indexed)
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		backoff -= backoff * p.Jitter * rand.Float64()
+	}
+
+	return time.Duration(backoff)
+}
+
+// shouldRetryEndpoint checks the endpoint against the policy
+func (p *RetryPolicy) shouldRetryEndpoint(endpoint rpcinterface.Endpoint) bool {
+	if p.ShouldRetryEndpoint != nil {
+		return p.ShouldRetryEndpoint(endpoint)
+	}
+	return endpoint.IsReadOnly()
+}
+
+// isRetryable returns true if the result of an attempt is worth retrying
+func (p *RetryPolicy) isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		// Never retry once the caller has given up
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryTransport is an http transport that retries failed requests according to a RetryPolicy
+type RetryTransport struct {
+	policy            *RetryPolicy
+	originalTransport http.RoundTripper
+}
+
+// NewRetryTransport returns a new transport that retries requests according to the policy
+func NewRetryTransport(policy *RetryPolicy, transport http.RoundTripper) *RetryTransport {
+	return &RetryTransport{
+		policy:            policy,
+		originalTransport: transport,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction, retrying as allowed by the policy, returning
+// a Response for the provided Request.
+func (t *RetryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	endpoint := rpcinterface.Endpoint(strings.TrimPrefix(r.URL.Path, "/"))
+	if t.policy.MaxAttempts <= 1 || !t.policy.shouldRetryEndpoint(endpoint) {
+		return t.originalTransport.RoundTrip(r)
+	}
+
+	// Body has to be buffered so that it can be sent again on each attempt
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := r.Context()
+	for attempt := 0; ; attempt++ {
+		req := r.Clone(ctx)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		resp, err := t.originalTransport.RoundTrip(req)
+		if attempt+1 >= t.policy.MaxAttempts || !t.policy.isRetryable(resp, err) {
+			return resp, err
+		}
+
+		backoff := t.policy.Backoff(attempt)
+		if resp != nil {
+			// The server knows best how long it will be unavailable
+			if retryAfter, ok := ParseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				if t.policy.MaxBackoff > 0 && retryAfter > t.policy.MaxBackoff {
+					return resp, err
+				}
+				backoff = retryAfter
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(backoff).After(deadline) {
+			// Not enough time left for another attempt, so return what we have
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// ParseRetryAfter parses a Retry-After header, in either seconds or HTTP date form
+func ParseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,33 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+// BaseTransport returns the transport requests are sent with, before any retry or cache transports are added
+// If base is nil, a new http.Transport is created. If base is an *http.Transport, a clone is used with the dialer set
+// and the TLS config added when the transport doesn't have one. Any other RoundTripper is returned as-is, and is
+// responsible for its own dialing and TLS
+func BaseTransport(base http.RoundTripper, dial rpcinterface.DialContextFunc, tlsConfig *tls.Config) http.RoundTripper {
+	var transport *http.Transport
+	switch typed := base.(type) {
+	case nil:
+		transport = &http.Transport{}
+	case *http.Transport:
+		transport = typed.Clone()
+	default:
+		return base
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	if dial != nil {
+		transport.DialContext = dial
+	}
+
+	return transport
+}
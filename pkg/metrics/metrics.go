@@ -0,0 +1,107 @@
+// Package metrics defines the hooks the RPC clients call to report their activity
+// Implement Recorder to send metrics to any backend, or use Prometheus to expose them in the Prometheus text format
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+// Direction is whether a peer protocol message was sent or received
+type Direction string
+
+const (
+	// DirectionSent is a message sent to the peer
+	DirectionSent Direction = "sent"
+
+	// DirectionReceived is a message received from the peer
+	DirectionReceived Direction = "received"
+)
+
+// RPCObservation describes a single completed RPC call
+type RPCObservation struct {
+	Service  rpcinterface.ServiceType
+	Endpoint rpcinterface.Endpoint
+	Duration time.Duration
+
+	// Success is true when the request completed and the RPC reported success
+	Success bool
+
+	// ChiaError is the error message returned by chia when the RPC reported success: false
+	ChiaError string
+
+	// ErrorCode is the chia consensus error code in ChiaError (such as DOUBLE_SPEND), if any
+	ErrorCode string
+
+	// Async is true for websocket requests sent in async mode. The response goes to the websocket handlers, so Success
+	// only means the request was sent, and Duration is how long it took to send
+	Async bool
+}
+
+// Recorder receives metrics from the clients. Implementations must be safe for concurrent use
+// Embed Noop to only implement some of the methods
+type Recorder interface {
+	// ObserveRPC is called when every RPC call completes
+	ObserveRPC(observation RPCObservation)
+
+	// ObserveCache is called for every request handled by a CachedTransport
+	ObserveCache(hit bool)
+
+	// ObserveWebsocketReconnect is called every time the websocket client reconnects after losing its connection
+	ObserveWebsocketReconnect()
+
+	// ObserveHandlerQueueDepth is called with the number of websocket messages waiting on handlers, whenever it changes
+	ObserveHandlerQueueDepth(depth int)
+
+	// ObservePeerMessage is called for every message sent to or received from a peer
+	ObservePeerMessage(direction Direction, messageType string)
+}
+
+// Noop is a Recorder that discards all metrics
+type Noop struct{}
+
+// ObserveRPC satisfies the Recorder interface
+func (Noop) ObserveRPC(observation RPCObservation) {}
+
+// ObserveCache satisfies the Recorder interface
+func (Noop) ObserveCache(hit bool) {}
+
+// ObserveWebsocketReconnect satisfies the Recorder interface
+func (Noop) ObserveWebsocketReconnect() {}
+
+// ObserveHandlerQueueDepth satisfies the Recorder interface
+func (Noop) ObserveHandlerQueueDepth(depth int) {}
+
+// ObservePeerMessage satisfies the Recorder interface
+func (Noop) ObservePeerMessage(direction Direction, messageType string) {}
+
+// Interceptor returns an interceptor that reports every RPC call to the recorder
+func Interceptor(recorder Recorder) rpcinterface.Interceptor {
+	return func(req *rpcinterface.Request, v rpcinterface.IResponse, next rpcinterface.Invoker) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next(req, v)
+
+		observation := RPCObservation{
+			Service:  req.Service,
+			Endpoint: req.Endpoint,
+			Duration: time.Since(start),
+			Success:  err == nil,
+			Async:    req.Async,
+		}
+		// The result isn't known yet for async websocket requests. resp is nil for websocket requests even in sync mode,
+		// so only v is used to tell if the call succeeded
+		if err == nil && !req.Async && v != nil && !v.IsSuccessful() {
+			chiaErr := &rpcinterface.ChiaRPCError{Message: v.GetRPCError()}
+			observation.Success = false
+			observation.ChiaError = chiaErr.Message
+			if code, ok := chiaErr.ConsensusErr(); ok {
+				observation.ErrorCode = string(code)
+			}
+		}
+		recorder.ObserveRPC(observation)
+
+		return resp, err
+	}
+}
@@ -0,0 +1,37 @@
+package metrics_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/metrics"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+// observations is a Recorder that keeps every RPC observation
+type observations struct {
+	metrics.Noop
+	rpc []metrics.RPCObservation
+}
+
+func (o *observations) ObserveRPC(observation metrics.RPCObservation) {
+	o.rpc = append(o.rpc, observation)
+}
+
+func TestInterceptorAsync(t *testing.T) {
+	recorder := &observations{}
+	interceptor := metrics.Interceptor(recorder)
+	next := func(req *rpcinterface.Request, v rpcinterface.IResponse) (*http.Response, error) {
+		return nil, nil
+	}
+
+	// The response isn't populated for async websocket requests, so it can't be used to tell if the call succeeded
+	_, err := interceptor(&rpcinterface.Request{Service: rpcinterface.ServiceWallet, Endpoint: "get_sync_status", Async: true}, &rpcinterface.Response{}, next)
+	require.NoError(t, err)
+	require.Len(t, recorder.rpc, 1)
+	require.True(t, recorder.rpc[0].Async)
+	require.True(t, recorder.rpc[0].Success)
+	require.Empty(t, recorder.rpc[0].ChiaError)
+}
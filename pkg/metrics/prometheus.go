@@ -0,0 +1,206 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the RPC latency histogram buckets, in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Prometheus is a Recorder that keeps metrics in memory and serves them in the Prometheus text exposition format
+// It has no dependencies, so it can be served from any http.ServeMux
+//
+//	recorder := metrics.NewPrometheus("myapp")
+//	client, err := rpc.NewClient(rpc.ConnectionModeHTTP, rpc.WithAutoConfig(), rpc.WithMetrics(recorder))
+//	http.Handle("/metrics", recorder)
+type Prometheus struct {
+	namespace string
+	buckets   []float64
+
+	lock sync.Mutex
+
+	rpcRequests   map[string]float64
+	rpcDurations  map[string]*histogram
+	cacheRequests map[string]float64
+	reconnects    float64
+	queueDepth    float64
+	peerMessages  map[string]float64
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewPrometheus returns a new Prometheus recorder. All metric names are prefixed with namespace if it is set
+func NewPrometheus(namespace string) *Prometheus {
+	return &Prometheus{
+		namespace:     namespace,
+		buckets:       DefaultBuckets,
+		rpcRequests:   map[string]float64{},
+		rpcDurations:  map[string]*histogram{},
+		cacheRequests: map[string]float64{},
+		peerMessages:  map[string]float64{},
+	}
+}
+
+// ObserveRPC satisfies the Recorder interface
+func (p *Prometheus) ObserveRPC(o RPCObservation) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// The outcome of async websocket requests isn't known, and the duration is only the time to send them
+	success := strconv.FormatBool(o.Success)
+	if o.Async {
+		success = "async"
+	}
+	p.rpcRequests[labels("service", o.Service.String(), "endpoint", string(o.Endpoint), "success", success, "error_code", o.ErrorCode)]++
+	if o.Async {
+		return
+	}
+
+	key := labels("service", o.Service.String(), "endpoint", string(o.Endpoint))
+	h, ok := p.rpcDurations[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(p.buckets))}
+		p.rpcDurations[key] = h
+	}
+	seconds := o.Duration.Seconds()
+	for i, bound := range p.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// ObserveCache satisfies the Recorder interface
+func (p *Prometheus) ObserveCache(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.cacheRequests[labels("result", result)]++
+}
+
+// ObserveWebsocketReconnect satisfies the Recorder interface
+func (p *Prometheus) ObserveWebsocketReconnect() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.reconnects++
+}
+
+// ObserveHandlerQueueDepth satisfies the Recorder interface
+func (p *Prometheus) ObserveHandlerQueueDepth(depth int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.queueDepth = float64(depth)
+}
+
+// ObservePeerMessage satisfies the Recorder interface
+func (p *Prometheus) ObservePeerMessage(direction Direction, messageType string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.peerMessages[labels("direction", string(direction), "type", messageType)]++
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = p.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format
+func (p *Prometheus) WriteTo(w io.Writer) (int64, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	buf := &bytes.Buffer{}
+
+	p.writeCounter(buf, "rpc_requests_total", "RPC requests by service, endpoint and result", p.rpcRequests)
+
+	name := p.name("rpc_request_duration_seconds")
+	fmt.Fprintf(buf, "# HELP %s RPC request latency\n# TYPE %s histogram\n", name, name)
+	for _, key := range sortedKeys(p.rpcDurations) {
+		h := p.rpcDurations[key]
+		for i, bound := range p.buckets {
+			fmt.Fprintf(buf, "%s_bucket{%s,le=\"%s\"} %d\n", name, key, formatFloat(bound), h.counts[i])
+		}
+		fmt.Fprintf(buf, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, key, h.count)
+		fmt.Fprintf(buf, "%s_sum{%s} %s\n", name, key, formatFloat(h.sum))
+		fmt.Fprintf(buf, "%s_count{%s} %d\n", name, key, h.count)
+	}
+
+	p.writeCounter(buf, "rpc_cache_requests_total", "Cached transport lookups by result", p.cacheRequests)
+	p.writeCounter(buf, "websocket_reconnects_total", "Websocket reconnections after losing the connection", map[string]float64{"": p.reconnects})
+
+	name = p.name("websocket_handler_queue_depth")
+	fmt.Fprintf(buf, "# HELP %s Websocket messages waiting on handlers\n# TYPE %s gauge\n%s %s\n", name, name, name, formatFloat(p.queueDepth))
+
+	p.writeCounter(buf, "peer_messages_total", "Peer protocol messages by direction and type", p.peerMessages)
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+// writeCounter writes a counter with all of its label sets
+func (p *Prometheus) writeCounter(buf *bytes.Buffer, metric string, help string, values map[string]float64) {
+	name := p.name(metric)
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, key := range sortedKeys(values) {
+		if key == "" {
+			fmt.Fprintf(buf, "%s %s\n", name, formatFloat(values[key]))
+			continue
+		}
+		fmt.Fprintf(buf, "%s{%s} %s\n", name, key, formatFloat(values[key]))
+	}
+}
+
+// name returns the full metric name, including the namespace
+func (p *Prometheus) name(metric string) string {
+	if p.namespace == "" {
+		return fmt.Sprintf("chia_%s", metric)
+	}
+	return fmt.Sprintf("%s_chia_%s", p.namespace, metric)
+}
+
+// labels formats label name/value pairs, which are also used as the map keys for each series
+func labels(pairs ...string) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, fmt.Sprintf("%s=\"%s\"", pairs[i], escapeLabel(pairs[i+1])))
+	}
+	return strings.Join(parts, ",")
+}
+
+// escapeLabel escapes a label value for the text format
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// formatFloat formats a value for the text format
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// sortedKeys returns the keys of the map in order, so the output is stable
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,51 @@
+package metrics_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/metrics"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+func TestPrometheus(t *testing.T) {
+	p := metrics.NewPrometheus("test")
+	p.ObserveRPC(metrics.RPCObservation{Service: rpcinterface.ServiceFullNode, Endpoint: "push_tx", Duration: 30 * time.Millisecond, ErrorCode: "DOUBLE_SPEND"})
+	p.ObserveRPC(metrics.RPCObservation{Service: rpcinterface.ServiceFullNode, Endpoint: "push_tx", Duration: 2 * time.Second, Success: true})
+	p.ObserveRPC(metrics.RPCObservation{Service: rpcinterface.ServiceFullNode, Endpoint: "push_tx", Duration: time.Millisecond, Success: true, Async: true})
+	p.ObserveCache(true)
+	p.ObserveCache(false)
+	p.ObserveCache(false)
+	p.ObserveWebsocketReconnect()
+	p.ObserveHandlerQueueDepth(3)
+	p.ObservePeerMessage(metrics.DirectionReceived, "new_peak")
+
+	buf := &bytes.Buffer{}
+	_, err := p.WriteTo(buf)
+	require.NoError(t, err)
+	out := buf.String()
+
+	require.Contains(t, out, "# TYPE test_chia_rpc_requests_total counter\n")
+	require.Contains(t, out, `test_chia_rpc_requests_total{service="full_node",endpoint="push_tx",success="false",error_code="DOUBLE_SPEND"} 1`+"\n")
+	require.Contains(t, out, `test_chia_rpc_requests_total{service="full_node",endpoint="push_tx",success="true",error_code=""} 1`+"\n")
+	require.Contains(t, out, `test_chia_rpc_requests_total{service="full_node",endpoint="push_tx",success="async",error_code=""} 1`+"\n")
+	require.Contains(t, out, "# TYPE test_chia_rpc_request_duration_seconds histogram\n")
+	require.Contains(t, out, `test_chia_rpc_request_duration_seconds_bucket{service="full_node",endpoint="push_tx",le="0.05"} 1`+"\n")
+	require.Contains(t, out, `test_chia_rpc_request_duration_seconds_bucket{service="full_node",endpoint="push_tx",le="2.5"} 2`+"\n")
+	require.Contains(t, out, `test_chia_rpc_request_duration_seconds_bucket{service="full_node",endpoint="push_tx",le="+Inf"} 2`+"\n")
+	require.Contains(t, out, `test_chia_rpc_request_duration_seconds_sum{service="full_node",endpoint="push_tx"} 2.03`+"\n")
+	require.Contains(t, out, `test_chia_rpc_cache_requests_total{result="hit"} 1`+"\n")
+	require.Contains(t, out, `test_chia_rpc_cache_requests_total{result="miss"} 2`+"\n")
+	require.Contains(t, out, "test_chia_websocket_reconnects_total 1\n")
+	require.Contains(t, out, "test_chia_websocket_handler_queue_depth 3\n")
+	require.Contains(t, out, `test_chia_peer_messages_total{direction="received",type="new_peak"} 1`+"\n")
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, out, rec.Body.String())
+	require.Contains(t, rec.Header().Get("Content-Type"), "text/plain; version=0.0.4")
+}
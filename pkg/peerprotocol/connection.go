@@ -16,6 +16,7 @@ import (
 	"github.com/gorilla/websocket"
 
 	"github.com/chia-network/go-chia-libs/pkg/config"
+	"github.com/chia-network/go-chia-libs/pkg/metrics"
 	"github.com/chia-network/go-chia-libs/pkg/protocols"
 )
 
@@ -32,6 +33,9 @@ type Connection struct {
 
 	handshakeTimeout time.Duration
 	conn             *websocket.Conn
+
+	// If set, sent and received messages are reported to metrics
+	metrics metrics.Recorder
 }
 
 // PeerResponseHandlerFunc is a function that will be called when a response is returned from a peer
@@ -208,7 +212,11 @@ func (c *Connection) Do(messageType protocols.ProtocolMessageType, data interfac
 		return err
 	}
 
-	return c.conn.WriteMessage(websocket.BinaryMessage, msgBytes)
+	err = c.conn.WriteMessage(websocket.BinaryMessage, msgBytes)
+	if err == nil {
+		c.observeMessage(metrics.DirectionSent, messageType)
+	}
+	return err
 }
 
 // ReadSync Reads for async responses over the connection in a synchronous fashion, blocking anything else
@@ -220,7 +228,11 @@ func (c *Connection) ReadSync(handler PeerResponseHandlerFunc) error {
 			return err
 
 		}
-		handler(protocols.DecodeMessage(bytes))
+		msg, err := protocols.DecodeMessage(bytes)
+		if err == nil {
+			c.observeMessage(metrics.DirectionReceived, msg.ProtocolMessageType)
+		}
+		handler(msg, err)
 	}
 }
 
@@ -237,7 +249,18 @@ func (c *Connection) ReadOne(timeout time.Duration) (*protocols.Message, error)
 	case <-ctxTimeout.Done():
 		return nil, fmt.Errorf("context cancelled: %v", ctxTimeout.Err())
 	case result := <-chBytes:
-		return protocols.DecodeMessage(result)
+		msg, err := protocols.DecodeMessage(result)
+		if err == nil {
+			c.observeMessage(metrics.DirectionReceived, msg.ProtocolMessageType)
+		}
+		return msg, err
+	}
+}
+
+// observeMessage reports a message to metrics, if set
+func (c *Connection) observeMessage(direction metrics.Direction, messageType protocols.ProtocolMessageType) {
+	if c.metrics != nil {
+		c.metrics.ObservePeerMessage(direction, messageType.String())
 	}
 }
 
@@ -3,6 +3,8 @@ package peerprotocol
 import (
 	"crypto/tls"
 	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/metrics"
 )
 
 // ConnectionOptionFunc can be used to customize a new Connection
@@ -39,3 +41,11 @@ func WithNetworkID(networkID string) ConnectionOptionFunc {
 		return nil
 	}
 }
+
+// WithMetrics reports every message sent to or received from the peer to the recorder
+func WithMetrics(recorder metrics.Recorder) ConnectionOptionFunc {
+	return func(c *Connection) error {
+		c.metrics = recorder
+		return nil
+	}
+}
@@ -1,5 +1,7 @@
 package protocols
 
+import "fmt"
+
 // ProtocolMessageType corresponds to ProtocolMessageTypes in Chia
 type ProtocolMessageType uint8
 
@@ -24,3 +26,22 @@ const (
 	// ProtocolMessageTypeRespondPeers respond_peers
 	ProtocolMessageTypeRespondPeers ProtocolMessageType = 44
 )
+
+// String returns the chia name of the message type, such as new_peak
+func (t ProtocolMessageType) String() string {
+	switch t {
+	case ProtocolMessageTypeHandshake:
+		return "handshake"
+	case ProtocolMessageTypeNewPeak:
+		return "new_peak"
+	case ProtocolMessageTypeRequestBlock:
+		return "request_block"
+	case ProtocolMessageTypeRespondBlock:
+		return "respond_block"
+	case ProtocolMessageTypeRequestPeers:
+		return "request_peers"
+	case ProtocolMessageTypeRespondPeers:
+		return "respond_peers"
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(t))
+}
@@ -0,0 +1,223 @@
+package publichttpclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces credentials anywhere they might be printed or logged
+const redacted = "[REDACTED]"
+
+// DefaultTokenRefreshLeeway is how long before a token expires that a new token is fetched
+const DefaultTokenRefreshLeeway = 30 * time.Second
+
+// CredentialProvider supplies the credentials sent with every request, such as an API key or bearer token
+// Implementations must be safe for concurrent use
+type CredentialProvider interface {
+	// Credentials returns the headers to set on the request
+	Credentials(ctx context.Context) (http.Header, error)
+}
+
+// Refresher is implemented by credential providers that can fetch new credentials
+// When the server responds with 401 Unauthorized, Refresh is called and the request is sent once more
+type Refresher interface {
+	// Refresh discards the current credentials, so the next call to Credentials fetches new ones
+	Refresh()
+}
+
+// HeaderCredentials sends a static set of headers, such as an API key, with every request
+// The header values are redacted when printed or logged
+type HeaderCredentials http.Header
+
+// Credentials satisfies the CredentialProvider interface
+func (h HeaderCredentials) Credentials(ctx context.Context) (http.Header, error) {
+	return http.Header(h).Clone(), nil
+}
+
+// String returns the header names with the values redacted
+func (h HeaderCredentials) String() string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, fmt.Sprintf("%s: %s", name, redacted))
+	}
+	return fmt.Sprintf("%v", names)
+}
+
+// LogValue satisfies slog.LogValuer so the header values are never logged
+func (h HeaderCredentials) LogValue() slog.Value {
+	return slog.StringValue(h.String())
+}
+
+// BearerToken sends the token in the Authorization header of every request
+// The token is redacted when printed or logged
+type BearerToken string
+
+// Credentials satisfies the CredentialProvider interface
+func (t BearerToken) Credentials(ctx context.Context) (http.Header, error) {
+	return bearerHeader(string(t)), nil
+}
+
+// String returns the redacted token
+func (t BearerToken) String() string {
+	return redacted
+}
+
+// GoString returns the redacted token, so %#v doesn't print it either
+func (t BearerToken) GoString() string {
+	return redacted
+}
+
+// LogValue satisfies slog.LogValuer so the token is never logged
+func (t BearerToken) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// TokenSource fetches a new bearer token, and the time it expires
+// A zero expiry means the token is used until the server rejects it
+type TokenSource func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// RefreshingToken is a bearer token that is fetched from a TokenSource when first needed, when it is about to expire,
+// and when the server rejects it
+type RefreshingToken struct {
+	source TokenSource
+	leeway time.Duration
+
+	lock   sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewRefreshingToken returns a bearer token provider that fetches tokens from source
+func NewRefreshingToken(source TokenSource) *RefreshingToken {
+	return &RefreshingToken{
+		source: source,
+		leeway: DefaultTokenRefreshLeeway,
+	}
+}
+
+// SetRefreshLeeway sets how long before the token expires that a new token is fetched
+func (t *RefreshingToken) SetRefreshLeeway(leeway time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.leeway = leeway
+}
+
+// Credentials satisfies the CredentialProvider interface
+func (t *RefreshingToken) Credentials(ctx context.Context) (http.Header, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.token == "" || (!t.expiry.IsZero() && time.Now().Add(t.leeway).After(t.expiry)) {
+		token, expiry, err := t.source(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error refreshing credentials: %w", err)
+		}
+		if token == "" {
+			return nil, fmt.Errorf("error refreshing credentials: token source returned an empty token")
+		}
+		t.token = token
+		t.expiry = expiry
+	}
+
+	return bearerHeader(t.token), nil
+}
+
+// Refresh satisfies the Refresher interface
+func (t *RefreshingToken) Refresh() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.token = ""
+	t.expiry = time.Time{}
+}
+
+// String returns a description of the token without the token itself
+func (t *RefreshingToken) String() string {
+	return fmt.Sprintf("RefreshingToken(%s)", redacted)
+}
+
+// LogValue satisfies slog.LogValuer so the token is never logged
+func (t *RefreshingToken) LogValue() slog.Value {
+	return slog.StringValue(t.String())
+}
+
+// bearerHeader returns the Authorization header for the token
+func bearerHeader(token string) http.Header {
+	header := http.Header{}
+	header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return header
+}
+
+// AuthTransport is an http transport that adds the credentials from a CredentialProvider to every request
+type AuthTransport struct {
+	provider          CredentialProvider
+	logger            *slog.Logger
+	originalTransport http.RoundTripper
+}
+
+// NewAuthTransport returns a new transport that authenticates requests with the provider
+func NewAuthTransport(provider CredentialProvider, logger *slog.Logger, transport http.RoundTripper) *AuthTransport {
+	return &AuthTransport{
+		provider:          provider,
+		logger:            logger,
+		originalTransport: transport,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction, adding the credentials to the request and refreshing them if they are
+// rejected, returning a Response for the provided Request.
+func (t *AuthTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	refresher, canRefresh := t.provider.(Refresher)
+
+	if !canRefresh {
+		return t.send(r, r.Body)
+	}
+
+	// Body has to be buffered so that it can be sent again with refreshed credentials
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := t.send(r, io.NopCloser(bytes.NewReader(body)))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// Only the endpoint is logged. Headers are never logged, since they contain the credentials
+	t.logger.Debug("Credentials rejected by server, refreshing", "endpoint", strings.TrimPrefix(r.URL.Path, "/"))
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	refresher.Refresh()
+
+	return t.send(r, io.NopCloser(bytes.NewReader(body)))
+}
+
+// send sends a copy of the request with the current credentials
+func (t *AuthTransport) send(r *http.Request, body io.ReadCloser) (*http.Response, error) {
+	credentials, err := t.provider.Credentials(r.Context())
+	if err != nil {
+		if body != nil {
+			_ = body.Close()
+		}
+		return nil, err
+	}
+
+	req := r.Clone(r.Context())
+	req.Body = body
+	for name, values := range credentials {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
+	return t.originalTransport.RoundTrip(req)
+}
@@ -4,16 +4,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
 	"github.com/google/uuid"
 
 	"github.com/chia-network/go-chia-libs/pkg/httpclient"
+	"github.com/chia-network/go-chia-libs/pkg/metrics"
 	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
 )
 
@@ -22,12 +23,50 @@ type HTTPClient struct {
 	baseURL *url.URL
 	logger  *slog.Logger
 
+	// interceptors wrap every call to Do
+	interceptors []rpcinterface.Interceptor
+
 	// If set > 0, will configure http requests with a cache
 	cacheValidTime time.Duration
 
+	// If set, responses are cached according to the policy instead of the default policy for cacheValidTime
+	cachePolicy *httpclient.CachePolicy
+
+	// responseCache is shared by the http clients for all services, so it can be invalidated in one place
+	responseCache *httpclient.ResponseCache
+
+	// If set, failed requests to read-only endpoints are retried according to the policy
+	retryPolicy *httpclient.RetryPolicy
+
+	// Requests are rate limited according to the policy. Throttled requests are retried even if no policy is set
+	rateLimitPolicy *RateLimitPolicy
+
+	// If set, requests are recorded to or replayed from fixture files in fixtureDir
+	fixtureMode httpclient.FixtureMode
+	fixtureDir  string
+
+	// If set, cache hits and misses are reported to metrics
+	metrics metrics.Recorder
+
+	// headers are added to every request by the transport, in addition to the credentials from the credential provider
+	headers HeaderCredentials
+
+	// If set, requests are authenticated with the credentials from the provider
+	credentials CredentialProvider
+
+	// decodeOptions limits the size of responses, and whether they are decoded as they are read
+	decodeOptions httpclient.DecodeOptions
+
 	// Request timeout
 	Timeout time.Duration
 
+	// If set, connections are opened with dialContext and requests are sent with transport
+	dialContext rpcinterface.DialContextFunc
+	transport   http.RoundTripper
+
+	// clientLock guards lazily creating the http clients for each service, so requests can be made concurrently
+	clientLock sync.Mutex
+
 	nodeClient *http.Client
 }
 
@@ -72,11 +111,89 @@ func (c *HTTPClient) SetLogHandler(handler slog.Handler) {
 	c.logger = slog.New(handler)
 }
 
+// SetHeaders sets headers that are sent with every request, replacing any previously set headers
+// The headers are treated as credentials, so they are redacted when logged
+// Must be set before the first request is made
+func (c *HTTPClient) SetHeaders(headers http.Header) {
+	c.headers = HeaderCredentials(headers.Clone())
+}
+
+// SetCredentialProvider sets the provider of the credentials that authenticate every request
+// Must be set before the first request is made
+func (c *HTTPClient) SetCredentialProvider(provider CredentialProvider) {
+	c.credentials = provider
+}
+
 // SetCacheValidTime sets how long cache should be valid for
 func (c *HTTPClient) SetCacheValidTime(validTime time.Duration) {
 	c.cacheValidTime = validTime
 }
 
+// SetCachePolicy sets which endpoints are cached, and for how long
+func (c *HTTPClient) SetCachePolicy(policy *httpclient.CachePolicy) {
+	c.cachePolicy = policy
+}
+
+// InvalidateCache removes the cached responses for the endpoints, or every cached response if no endpoints are provided
+func (c *HTTPClient) InvalidateCache(endpoints ...rpcinterface.Endpoint) {
+	c.clientLock.Lock()
+	defer c.clientLock.Unlock()
+	if c.responseCache != nil {
+		c.responseCache.Invalidate(endpoints...)
+	}
+}
+
+// SetRateLimitPolicy sets the client side rate limits, and how requests throttled by the server are retried
+func (c *HTTPClient) SetRateLimitPolicy(policy *RateLimitPolicy) {
+	c.rateLimitPolicy = policy
+}
+
+// SetMaxResponseSize sets the largest response body, in bytes, that will be read. Unlimited if 0
+// Larger responses fail with httpclient.ErrResponseTooLarge instead of being read into memory
+// Must be set before the first request is made
+func (c *HTTPClient) SetMaxResponseSize(maxBytes int64) {
+	c.decodeOptions.MaxResponseSize = maxBytes
+}
+
+// SetStreamingDecode decodes responses as they are read, instead of reading the entire body into memory first
+// The body of the http.Response returned by Do is empty when streaming
+func (c *HTTPClient) SetStreamingDecode(streaming bool) {
+	c.decodeOptions.Streaming = streaming
+}
+
+// SetRetryPolicy sets the policy used to retry failed requests
+func (c *HTTPClient) SetRetryPolicy(policy *httpclient.RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// SetDialer sets the function used to open connections, such as to connect through a unix socket
+// Must be set before the first request is made
+func (c *HTTPClient) SetDialer(dial rpcinterface.DialContextFunc) {
+	c.dialContext = dial
+}
+
+// SetTransport sets the base transport requests are sent with. Retry and cache transports still wrap it when enabled
+// Must be set before the first request is made
+func (c *HTTPClient) SetTransport(transport http.RoundTripper) {
+	c.transport = transport
+}
+
+// SetFixtures records requests to, or replays responses from, the fixture files in dir
+func (c *HTTPClient) SetFixtures(mode httpclient.FixtureMode, dir string) {
+	c.fixtureMode = mode
+	c.fixtureDir = dir
+}
+
+// SetMetrics sets the recorder that cache hits and misses are reported to
+func (c *HTTPClient) SetMetrics(recorder metrics.Recorder) {
+	c.metrics = recorder
+}
+
+// AddInterceptor adds an interceptor that wraps every request made with Do
+func (c *HTTPClient) AddInterceptor(interceptor rpcinterface.Interceptor) {
+	c.interceptors = append(c.interceptors, interceptor)
+}
+
 // NewRequest creates an RPC request for the specified service
 func (c *HTTPClient) NewRequest(service rpcinterface.ServiceType, rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
 	// Always POST
@@ -126,13 +243,21 @@ func (c *HTTPClient) NewRequest(service rpcinterface.ServiceType, rpcEndpoint rp
 	}
 
 	return &rpcinterface.Request{
-		Service: service,
-		Request: req,
+		Service:  service,
+		Endpoint: rpcEndpoint,
+		Data:     opt,
+		Request:  req,
 	}, nil
 }
 
 // Do sends an RPC request and returns the RPC response.
+// The request is bound to req.Context(), so cancellation and deadlines abort the call
 func (c *HTTPClient) Do(req *rpcinterface.Request, v rpcinterface.IResponse) (*http.Response, error) {
+	return rpcinterface.ChainInterceptors(c.interceptors, c.do)(req, v)
+}
+
+// do sends the request without any interceptors applied
+func (c *HTTPClient) do(req *rpcinterface.Request, v rpcinterface.IResponse) (*http.Response, error) {
 	client, err := c.httpClientForService(req.Service)
 	if err != nil {
 		return nil, err
@@ -143,13 +268,7 @@ func (c *HTTPClient) Do(req *rpcinterface.Request, v rpcinterface.IResponse) (*h
 		return nil, err
 	}
 
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, resp.Body)
-		} else {
-			err = json.NewDecoder(resp.Body).Decode(v)
-		}
-	}
+	err = httpclient.DecodeResponse(resp, req.Endpoint, v, c.decodeOptions)
 
 	return resp, err
 }
@@ -162,10 +281,36 @@ func (c *HTTPClient) Close() error {
 func (c *HTTPClient) generateHTTPClientForService(service rpcinterface.ServiceType) (*http.Client, error) {
 	var transport http.RoundTripper
 
-	transport = &http.Transport{}
+	transport = httpclient.BaseTransport(c.transport, c.dialContext, nil)
+
+	// Static headers and credentials are added below the throttle transport, so they never reach the request
+	// seen by the fixture and cache transports, and retried requests get fresh credentials
+	if len(c.headers) > 0 {
+		transport = NewAuthTransport(c.headers, c.logger, transport)
+	}
+	if c.credentials != nil {
+		transport = NewAuthTransport(c.credentials, c.logger, transport)
+	}
+
+	rateLimitPolicy := c.rateLimitPolicy
+	if rateLimitPolicy == nil {
+		rateLimitPolicy = DefaultRateLimitPolicy()
+	}
+	transport = NewThrottleTransport(rateLimitPolicy, c.logger, transport)
 
-	if c.cacheValidTime > 0 {
-		transport = httpclient.NewCachedTransport(c.cacheValidTime, transport)
+	if c.retryPolicy != nil {
+		transport = httpclient.NewRetryTransport(c.retryPolicy, transport)
+	}
+
+	// Added below the fixture and cache transports, so oversized responses are never recorded or cached
+	if c.decodeOptions.MaxResponseSize > 0 {
+		transport = httpclient.NewSizeLimitTransport(c.decodeOptions.MaxResponseSize, transport)
+	}
+
+	transport = httpclient.FixtureTransport(c.fixtureMode, c.fixtureDir, service, transport)
+
+	if cache := c.sharedResponseCache(); cache != nil {
+		transport = httpclient.NewCachedTransportWithCache(cache, transport)
 	}
 
 	client := &http.Client{
@@ -176,8 +321,28 @@ func (c *HTTPClient) generateHTTPClientForService(service rpcinterface.ServiceTy
 	return client, nil
 }
 
+// sharedResponseCache returns the response cache for all services, or nil if caching isn't enabled
+// Must be called with clientLock held
+func (c *HTTPClient) sharedResponseCache() *httpclient.ResponseCache {
+	if c.responseCache == nil {
+		policy := c.cachePolicy
+		if policy == nil && c.cacheValidTime > 0 {
+			policy = httpclient.DefaultCachePolicy(c.cacheValidTime)
+		}
+		if policy == nil {
+			return nil
+		}
+		c.responseCache = httpclient.NewResponseCache(policy)
+		c.responseCache.SetMetrics(c.metrics)
+	}
+	return c.responseCache
+}
+
 // httpClientForService returns the proper http client to use with the service
 func (c *HTTPClient) httpClientForService(service rpcinterface.ServiceType) (*http.Client, error) {
+	c.clientLock.Lock()
+	defer c.clientLock.Unlock()
+
 	var (
 		client *http.Client
 		err    error
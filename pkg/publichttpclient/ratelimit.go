@@ -0,0 +1,249 @@
+package publichttpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/httpclient"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+// RateLimit is a token bucket allowing Rate requests per second on average, with bursts of up to Burst requests
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitPolicy configures client side rate limiting and how throttled responses from the server are handled
+type RateLimitPolicy struct {
+	// Global limits all requests combined. Unlimited if nil
+	Global *RateLimit
+
+	// Endpoints limits individual endpoints, in addition to the global limit
+	Endpoints map[rpcinterface.Endpoint]RateLimit
+
+	// MaxRetries is the number of times a request is retried after a 429 response, or a 503 response with Retry-After
+	// to a read-only endpoint. Defaults to 3 when not set. Set to -1 to disable retries
+	// Other 503 responses are left to the retry policy, see rpc.WithRetryPolicy
+	MaxRetries int
+
+	// RetryBackoff is the wait before retrying when the server doesn't send Retry-After. Doubles on every retry
+	RetryBackoff time.Duration
+
+	// MaxRetryAfter is the longest the client will wait for a Retry-After. Longer waits return the throttled response
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRateLimitPolicy doesn't limit requests, but retries throttled requests after the wait requested by the server
+func DefaultRateLimitPolicy() *RateLimitPolicy {
+	return &RateLimitPolicy{
+		MaxRetries:    3,
+		RetryBackoff:  time.Second,
+		MaxRetryAfter: time.Minute,
+	}
+}
+
+// withDefaults returns a copy of the policy with any unset values filled in
+func (p *RateLimitPolicy) withDefaults() *RateLimitPolicy {
+	defaults := DefaultRateLimitPolicy()
+	policy := *p
+	if policy.MaxRetries == 0 {
+		policy.MaxRetries = defaults.MaxRetries
+	}
+	if policy.MaxRetries < 0 {
+		policy.MaxRetries = 0
+	}
+	if policy.RetryBackoff <= 0 {
+		policy.RetryBackoff = defaults.RetryBackoff
+	}
+	if policy.MaxRetryAfter <= 0 {
+		policy.MaxRetryAfter = defaults.MaxRetryAfter
+	}
+	return &policy
+}
+
+// tokenBucket is a thread safe token bucket rate limiter
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	lock        sync.Mutex
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	burst := math.Max(float64(limit.Burst), 1)
+	return &tokenBucket{
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long to wait before using it
+func (b *tokenBucket) reserve() time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := time.Now()
+	var wait time.Duration
+	if b.pausedUntil.After(now) {
+		wait = b.pausedUntil.Sub(now)
+	}
+	if b.rate <= 0 {
+		return wait
+	}
+
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens < 0 {
+		wait = max(wait, time.Duration(-b.tokens/b.rate*float64(time.Second)))
+	}
+	return wait
+}
+
+// pause stops requests until the time, such as when the server sends Retry-After
+func (b *tokenBucket) pause(until time.Time) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+// ThrottleTransport is an http transport that rate limits requests and retries requests the server throttled
+type ThrottleTransport struct {
+	policy            *RateLimitPolicy
+	logger            *slog.Logger
+	originalTransport http.RoundTripper
+
+	global    *tokenBucket
+	endpoints map[rpcinterface.Endpoint]*tokenBucket
+}
+
+// NewThrottleTransport returns a new transport that limits requests according to the policy
+func NewThrottleTransport(policy *RateLimitPolicy, logger *slog.Logger, transport http.RoundTripper) *ThrottleTransport {
+	policy = policy.withDefaults()
+	t := &ThrottleTransport{
+		policy:            policy,
+		logger:            logger,
+		originalTransport: transport,
+		endpoints:         map[rpcinterface.Endpoint]*tokenBucket{},
+	}
+	// Buckets always exist, even if unlimited, so a Retry-After from the server pauses every request
+	global := RateLimit{}
+	if policy.Global != nil {
+		global = *policy.Global
+	}
+	t.global = newTokenBucket(global)
+	for endpoint, limit := range policy.Endpoints {
+		t.endpoints[endpoint] = newTokenBucket(limit)
+	}
+	return t
+}
+
+// RoundTrip executes a single HTTP transaction, waiting for the rate limit and retrying throttled requests, returning
+// a Response for the provided Request.
+func (t *ThrottleTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	endpoint := rpcinterface.Endpoint(strings.TrimPrefix(r.URL.Path, "/"))
+	ctx := r.Context()
+
+	// Body has to be buffered so that it can be sent again on each attempt
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	backoff := t.policy.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(ctx, endpoint); err != nil {
+			return nil, err
+		}
+
+		req := r.Clone(ctx)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		resp, err := t.originalTransport.RoundTrip(req)
+		if err != nil || !t.isThrottled(endpoint, resp) {
+			return resp, err
+		}
+
+		retryAfter, ok := httpclient.ParseRetryAfter(resp.Header.Get("Retry-After"))
+		if !ok {
+			retryAfter = backoff
+			backoff *= 2
+		}
+		t.logger.Warn("Request throttled by server", "endpoint", endpoint, "status", resp.StatusCode, "retry_after", retryAfter.String(), "attempt", attempt+1)
+
+		if attempt >= t.policy.MaxRetries || retryAfter > t.policy.MaxRetryAfter {
+			return resp, nil
+		}
+		until := time.Now().Add(min(retryAfter, t.policy.MaxRetryAfter))
+		if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
+			// Not enough time left for another attempt, so return what we have
+			return resp, nil
+		}
+
+		// Every request to the endpoint waits for the retry, not just this one
+		t.bucket(endpoint).pause(until)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+}
+
+// bucket returns the bucket for the endpoint, or the global bucket if the endpoint isn't limited on its own
+func (t *ThrottleTransport) bucket(endpoint rpcinterface.Endpoint) *tokenBucket {
+	if bucket, ok := t.endpoints[endpoint]; ok {
+		return bucket
+	}
+	return t.global
+}
+
+// wait blocks until the request is allowed by the global and endpoint limits
+func (t *ThrottleTransport) wait(ctx context.Context, endpoint rpcinterface.Endpoint) error {
+	wait := t.global.reserve()
+	if bucket, ok := t.endpoints[endpoint]; ok {
+		wait = max(wait, bucket.reserve())
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	t.logger.Debug("Waiting for rate limit", "endpoint", endpoint, "wait", wait.String())
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isThrottled returns true if the server throttled the request and it may be retried
+// A 503 is only throttling when the server says when to retry, and is only retried for read-only endpoints since the
+// request might have been processed. Other 503 responses are left to the retry policy
+func (t *ThrottleTransport) isThrottled(endpoint rpcinterface.Endpoint, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return resp.Header.Get("Retry-After") != "" && endpoint.IsReadOnly()
+	}
+	return false
+}
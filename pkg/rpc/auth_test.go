@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/publichttpclient"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+func TestWithCredentials(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var lock sync.Mutex
+	var authorizations []string
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		lock.Unlock()
+
+		if r.Header.Get("X-Api-Key") != "static-key" || r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"success": true}`)
+	})
+
+	tokens := 0
+	provider := publichttpclient.NewRefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		tokens++
+		return fmt.Sprintf("token-%d", tokens), time.Time{}, nil
+	})
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	logs := &bytes.Buffer{}
+	client, err := NewClient(ConnectionModePublicHTTP, WithPublicConfig(), WithBaseURL(serverURL),
+		WithLogHandler(slog.NewTextHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		WithHeaders(http.Header{"X-Api-Key": []string{"static-key"}}),
+		WithCredentialProvider(provider),
+		WithInterceptors(func(req *rpcinterface.Request, v rpcinterface.IResponse, next rpcinterface.Invoker) (*http.Response, error) {
+			// Static headers are added by the transport, so they are never visible on the request
+			assert.Empty(t, req.Request.Header.Get("X-Api-Key"))
+			return next(req, v)
+		}),
+	)
+	require.NoError(t, err)
+
+	// The first token is rejected, so it is refreshed and the request is sent again
+	_, _, err = client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	lock.Lock()
+	require.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, authorizations)
+	lock.Unlock()
+
+	// The refreshed token is reused
+	_, _, err = client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, 2, tokens)
+
+	// Credentials never make it into logs
+	slog.New(slog.NewTextHandler(logs, nil)).Info("credentials", "provider", provider, "token", publichttpclient.BearerToken("secret-token"), "headers", publichttpclient.HeaderCredentials{"X-Api-Key": []string{"static-key"}})
+	require.Contains(t, logs.String(), "Credentials rejected by server")
+	require.NotContains(t, logs.String(), "token-")
+	require.NotContains(t, logs.String(), "secret-token")
+	require.NotContains(t, logs.String(), "static-key")
+	require.Equal(t, "[REDACTED]", fmt.Sprintf("%v", publichttpclient.BearerToken("secret-token")))
+}
@@ -0,0 +1,208 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBulkWorkers is the number of requests a bulk fetch runs concurrently when not specified
+	DefaultBulkWorkers = 4
+
+	// DefaultBulkRetries is the number of times a failed chunk is retried when not specified
+	DefaultBulkRetries = 2
+
+	// DefaultBulkRetryBackoff is the wait before the first retry of a failed chunk. Doubles on every retry
+	DefaultBulkRetryBackoff = 500 * time.Millisecond
+)
+
+// BulkFetchOptions controls how a bulk fetch splits and runs its requests
+type BulkFetchOptions struct {
+	// ChunkSize is the number of items requested per call. Each bulk method has its own default
+	ChunkSize int
+
+	// Workers is the maximum number of requests in flight at once
+	Workers int
+
+	// Retries is the number of times a failed chunk is retried before the bulk fetch fails. Set to -1 to disable retries
+	Retries int
+
+	// RetryBackoff is the wait before the first retry of a chunk. Doubles on every retry
+	RetryBackoff time.Duration
+}
+
+// withDefaults returns a copy of the options with any unset values filled in
+func (o *BulkFetchOptions) withDefaults(defaultChunkSize int) BulkFetchOptions {
+	opts := BulkFetchOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = defaultChunkSize
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultBulkWorkers
+	}
+	if opts.Retries == 0 {
+		opts.Retries = DefaultBulkRetries
+	}
+	if opts.Retries < 0 {
+		opts.Retries = 0
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = DefaultBulkRetryBackoff
+	}
+	return opts
+}
+
+// ChunkError is returned when a chunk of a bulk fetch fails after all retries
+type ChunkError struct {
+	// Chunk is the index of the chunk that failed
+	Chunk int
+	// Err is the error from the last attempt
+	Err error
+}
+
+// Error satisfies the error interface
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("bulk fetch chunk %d failed: %s", e.Chunk, e.Err.Error())
+}
+
+// Unwrap returns the underlying error
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// bulkFetch calls fetch for every chunk using a bounded pool of workers, retrying chunks that fail
+// Results are returned in chunk order. If a chunk fails, the chunks after it are cancelled while the chunks before it
+// are left to finish, and the results of all chunks before the failed chunk are returned along with the error, so no
+// contiguous progress is lost
+func bulkFetch[T any](ctx context.Context, chunks int, opts BulkFetchOptions, fetch func(ctx context.Context, chunk int) ([]T, error)) ([]T, error) {
+	results := make([][]T, chunks)
+
+	// failedChunk is the lowest chunk that failed on its own, rather than being cancelled because an earlier chunk
+	// failed. Chunks after it are cancelled, and no more are started
+	var (
+		mu          sync.Mutex
+		failedChunk = chunks
+		failedErr   *ChunkError
+		cancels     = make([]context.CancelFunc, chunks)
+	)
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+
+	// start returns the context for the chunk, or false if the chunk comes after a failed chunk and shouldn't run
+	start := func(chunk int) (context.Context, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if chunk > failedChunk {
+			return nil, false
+		}
+		chunkCtx, cancel := context.WithCancel(ctx)
+		cancels[chunk] = cancel
+		return chunkCtx, true
+	}
+	finish := func(chunk int, result []T, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		cancels[chunk]()
+		cancels[chunk] = nil
+		if err == nil {
+			results[chunk] = result
+			return
+		}
+		if chunk > failedChunk || ctx.Err() != nil {
+			return
+		}
+		failedChunk = chunk
+		failedErr = &ChunkError{Chunk: chunk, Err: err}
+		for later := chunk + 1; later < chunks; later++ {
+			if cancels[later] != nil {
+				cancels[later]()
+			}
+		}
+		stopOnce.Do(func() { close(stop) })
+	}
+
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers && i < chunks; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range work {
+				chunkCtx, ok := start(chunk)
+				if !ok {
+					continue
+				}
+				result, err := fetchChunkWithRetry(chunkCtx, chunk, opts, fetch)
+				finish(chunk, result, err)
+			}
+		}()
+	}
+
+queue:
+	for chunk := 0; chunk < chunks; chunk++ {
+		select {
+		case work <- chunk:
+		case <-stop:
+			break queue
+		case <-ctx.Done():
+			break queue
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	var items []T
+	for chunk := 0; chunk < chunks; chunk++ {
+		if results[chunk] == nil {
+			if failedErr != nil {
+				return items, failedErr
+			}
+			return items, ctx.Err()
+		}
+		items = append(items, results[chunk]...)
+	}
+
+	return items, nil
+}
+
+// fetchChunkWithRetry fetches a single chunk, retrying with exponential backoff
+func fetchChunkWithRetry[T any](ctx context.Context, chunk int, opts BulkFetchOptions, fetch func(ctx context.Context, chunk int) ([]T, error)) ([]T, error) {
+	backoff := opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		result, err := fetch(ctx, chunk)
+		if err == nil {
+			if result == nil {
+				result = []T{}
+			}
+			return result, nil
+		}
+		// Don't retry once the fetch has been cancelled
+		if attempt >= opts.Retries || ctx.Err() != nil {
+			return nil, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// chunkOf returns the items in the chunk with the given index
+func chunkOf[T any](items []T, chunk int, chunkSize int) []T {
+	start := chunk * chunkSize
+	end := start + chunkSize
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
@@ -0,0 +1,171 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+func TestFetchBlocks(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	var (
+		mu       sync.Mutex
+		attempts = map[int]int{}
+	)
+	mux.HandleFunc("/get_blocks", func(w http.ResponseWriter, r *http.Request) {
+		opts := &GetBlocksOptions{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(opts))
+
+		mu.Lock()
+		attempts[opts.Start]++
+		attempt := attempts[opts.Start]
+		mu.Unlock()
+
+		// Fail the first attempt of one chunk to exercise the per chunk retry
+		if opts.Start == 20 && attempt == 1 {
+			_, _ = fmt.Fprint(w, `{"success": false, "error": "try again"}`)
+			return
+		}
+
+		var blocks []string
+		for height := opts.Start; height < opts.End; height++ {
+			blocks = append(blocks, fmt.Sprintf(`{"reward_chain_block": {"height": %d}}`, height))
+		}
+		_, _ = fmt.Fprintf(w, `{"success": true, "blocks": [%s]}`, strings.Join(blocks, ","))
+	})
+
+	blocks, err := client.FullNodeService.FetchBlocks(context.Background(), &FetchBlocksOptions{
+		Start: 5,
+		End:   47,
+		Bulk: &BulkFetchOptions{
+			ChunkSize:    5,
+			Workers:      3,
+			RetryBackoff: time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, blocks, 42)
+	for i, block := range blocks {
+		require.Equal(t, uint32(5+i), block.RewardChainBlock.Height)
+	}
+	mu.Lock()
+	require.Equal(t, 2, attempts[20])
+	mu.Unlock()
+}
+
+func TestFetchBlocksChunkError(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_blocks", func(w http.ResponseWriter, r *http.Request) {
+		opts := &GetBlocksOptions{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(opts))
+		if opts.Start >= 10 {
+			_, _ = fmt.Fprint(w, `{"success": false, "error": "Block not found"}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"success": true, "blocks": [{"reward_chain_block": {"height": %d}}]}`, opts.Start)
+	})
+
+	blocks, err := client.FullNodeService.FetchBlocks(context.Background(), &FetchBlocksOptions{
+		Start: 0,
+		End:   30,
+		Bulk:  &BulkFetchOptions{ChunkSize: 5, Workers: 1, Retries: -1},
+	})
+	var chunkErr *ChunkError
+	require.ErrorAs(t, err, &chunkErr)
+	require.Equal(t, 2, chunkErr.Chunk)
+	// The chunks before the failure are kept
+	require.Len(t, blocks, 2)
+}
+
+func TestFetchBlocksEarlierChunksFinish(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_blocks", func(w http.ResponseWriter, r *http.Request) {
+		opts := &GetBlocksOptions{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(opts))
+		switch opts.Start {
+		case 0:
+			// Still running when the next chunk fails
+			time.Sleep(100 * time.Millisecond)
+		case 5:
+			_, _ = fmt.Fprint(w, `{"success": false, "error": "Block not found"}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"success": true, "blocks": [{"reward_chain_block": {"height": %d}}]}`, opts.Start)
+	})
+
+	blocks, err := client.FullNodeService.FetchBlocks(context.Background(), &FetchBlocksOptions{
+		Start: 0,
+		End:   15,
+		Bulk:  &BulkFetchOptions{ChunkSize: 5, Workers: 3, Retries: -1},
+	})
+	var chunkErr *ChunkError
+	require.ErrorAs(t, err, &chunkErr)
+	require.Equal(t, 1, chunkErr.Chunk)
+	require.Len(t, blocks, 1)
+	require.Equal(t, uint32(0), blocks[0].RewardChainBlock.Height)
+
+	_, err = client.FullNodeService.FetchBlocks(context.Background(), nil)
+	require.Error(t, err)
+}
+
+func TestFetchCoinRecordsByNames(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	mux.HandleFunc("/get_coin_records_by_names", func(w http.ResponseWriter, r *http.Request) {
+		opts := &GetCoinRecordsByNamesOptions{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(opts))
+		assert.LessOrEqual(t, len(opts.Names), 2)
+		assert.True(t, opts.IncludeSpentCoins)
+
+		mu.Lock()
+		requests++
+		mu.Unlock()
+
+		var records []string
+		for _, name := range opts.Names {
+			records = append(records, fmt.Sprintf(`{"coin": {"parent_coin_info": "%s", "amount": 1}}`, name))
+		}
+		_, _ = fmt.Fprintf(w, `{"success": true, "coin_records": [%s]}`, strings.Join(records, ","))
+	})
+
+	var names []types.Bytes32
+	for i := 0; i < 5; i++ {
+		names = append(names, types.Bytes32{byte(i)})
+	}
+	records, err := client.FullNodeService.FetchCoinRecordsByNames(context.Background(), &GetCoinRecordsByNamesOptions{
+		Names:             names,
+		IncludeSpentCoins: true,
+	}, &BulkFetchOptions{ChunkSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, 3, requests)
+	require.Len(t, records, 5)
+	for i, record := range records {
+		require.Equal(t, names[i], record.Coin.ParentCoinInfo)
+	}
+
+	_, err = client.FullNodeService.FetchCoinRecordsByNames(context.Background(), nil, nil)
+	require.Error(t, err)
+	_, err = client.FullNodeService.FetchCoinRecordsByParentIDs(context.Background(), nil, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,185 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/httpclient"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+func TestWithCachePolicy(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	require.NoError(t, WithCachePolicy(&httpclient.CachePolicy{
+		TTLs: map[rpcinterface.Endpoint]time.Duration{
+			"get_blockchain_state": time.Minute,
+			"get_block":            time.Minute,
+			"get_network_info":     50 * time.Millisecond,
+
+			"get_coin_record_by_name": time.Minute,
+		},
+		MaxEntries: 2,
+	})(client.activeClient))
+
+	var lock sync.Mutex
+	calls := map[string]int{}
+	count := func(endpoint string) int {
+		lock.Lock()
+		defer lock.Unlock()
+		return calls[endpoint]
+	}
+	release := make(chan struct{})
+	handle := func(endpoint string, body string) {
+		mux.HandleFunc("/"+endpoint, func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			calls[endpoint]++
+			lock.Unlock()
+			if endpoint == "get_blockchain_state" {
+				<-release
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, body)
+		})
+	}
+	handle("get_blockchain_state", `{"success": true}`)
+	handle("get_block", `{"success": true}`)
+	handle("get_network_info", `{"success": true}`)
+	handle("push_tx", `{"success": true}`)
+	handle("get_coin_record_by_name", `{"success": false, "error": "Coin record 0x00 not found"}`)
+
+	// Concurrent identical requests are only sent once
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := client.FullNodeService.GetBlockchainState()
+			assert.NoError(t, err)
+		}()
+	}
+	require.Eventually(t, func() bool { return count("get_blockchain_state") == 1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	_, _, err := client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, 1, count("get_blockchain_state"))
+
+	// Endpoints that aren't allowed are never cached
+	for i := 0; i < 2; i++ {
+		_, _, err = client.FullNodeService.PushTX(&FullNodePushTXOptions{})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, count("push_tx"))
+
+	// RPC errors are not cached
+	for i := 0; i < 2; i++ {
+		_, _, err = client.FullNodeService.GetCoinRecordByName(&GetCoinRecordByNameOptions{})
+		require.Error(t, err)
+	}
+	require.Equal(t, 2, count("get_coin_record_by_name"))
+
+	// Explicit invalidation
+	client.InvalidateCache("get_blockchain_state")
+	_, _, err = client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, 2, count("get_blockchain_state"))
+
+	// Least recently used responses are evicted once the cache is full
+	for _, hash := range []byte{1, 2, 3, 1} {
+		_, _, err = client.FullNodeService.GetBlock(&GetBlockOptions{HeaderHash: types.Bytes32{hash}})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 4, count("get_block"))
+
+	// Responses expire after their TTL
+	for i := 0; i < 2; i++ {
+		_, _, err = client.FullNodeService.GetNetworkInfo(&GetNetworkInfoOptions{})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, count("get_network_info"))
+	time.Sleep(60 * time.Millisecond)
+	_, _, err = client.FullNodeService.GetNetworkInfo(&GetNetworkInfoOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 2, count("get_network_info"))
+}
+
+func TestCacheLimits(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	require.NoError(t, WithCachePolicy(&httpclient.CachePolicy{
+		TTLs: map[rpcinterface.Endpoint]time.Duration{
+			"get_blocks":           time.Minute,
+			"get_blockchain_state": time.Minute,
+		},
+		MaxResponseBytes: 1024,
+	})(client.activeClient))
+
+	var blocksCalls, stateCalls atomic.Int32
+	mux.HandleFunc("/get_blocks", func(w http.ResponseWriter, r *http.Request) {
+		blocksCalls.Add(1)
+		blocks := strings.TrimSuffix(strings.Repeat(`{"reward_chain_block": {"height": 1}},`, 100), ",")
+		_, _ = fmt.Fprintf(w, `{"success": true, "blocks": [%s]}`, blocks)
+	})
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		if stateCalls.Add(1) == 1 {
+			started <- struct{}{}
+			<-release
+		}
+		_, _ = fmt.Fprint(w, `{"success": true}`)
+	})
+
+	// Responses over the size limit are returned in full, but not cached
+	for i := 0; i < 2; i++ {
+		blocks, _, err := client.FullNodeService.GetBlocks(&GetBlocksOptions{Start: 0, End: 100})
+		require.NoError(t, err)
+		require.Len(t, blocks.Blocks.MustGet(), 100)
+	}
+	require.Equal(t, int32(2), blocksCalls.Load())
+
+	// A response to a request sent before the cache was invalidated isn't cached
+	done := make(chan error)
+	go func() {
+		_, _, err := client.FullNodeService.GetBlockchainState()
+		done <- err
+	}()
+	<-started
+	client.InvalidateCache()
+	close(release)
+	require.NoError(t, <-done)
+	_, _, err := client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, int32(2), stateCalls.Load())
+}
+
+func TestDefaultCachePolicy(t *testing.T) {
+	policy := httpclient.DefaultCachePolicy(time.Minute)
+	require.Equal(t, time.Minute, policy.TTLs["get_block"])
+	require.Equal(t, httpclient.VolatileCacheTTL, policy.TTLs["get_blockchain_state"])
+	require.NotContains(t, policy.TTLs, rpcinterface.Endpoint("get_coin_records_by_puzzle_hash"))
+	require.NotContains(t, policy.TTLs, rpcinterface.Endpoint("get_transactions"))
+
+	// Height-indexed lookups change after a reorg, so only hash-indexed lookups get the full TTL
+	require.Equal(t, time.Minute, policy.TTLs["get_block_record"])
+	require.Equal(t, httpclient.VolatileCacheTTL, policy.TTLs["get_blocks"])
+	require.Equal(t, httpclient.VolatileCacheTTL, policy.TTLs["get_block_records"])
+	require.Equal(t, httpclient.VolatileCacheTTL, policy.TTLs["get_block_record_by_height"])
+
+	// Lookups that change as the wallet, farm or data layer changes aren't cached
+	for _, endpoint := range []rpcinterface.Endpoint{"get_transaction", "get_transaction_count", "nft_get_info", "nft_get_nfts", "get_keys_values", "get_wallet_balance", "get_ips_after_timestamp", "get_harvesters", "get_plots"} {
+		require.NotContains(t, policy.TTLs, endpoint)
+	}
+}
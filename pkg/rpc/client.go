@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/google/uuid"
 
@@ -29,6 +30,10 @@ type Client struct {
 	CrawlerService   *CrawlerService
 	DataLayerService *DataLayerService
 	TimelordService  *TimelordService
+
+	// versions caches the version of each service, for RequireVersion
+	versions     map[rpcinterface.ServiceType]versionLookup
+	versionsLock sync.Mutex
 }
 
 // ConnectionMode specifies the method used to connect to the server (HTTP or Websocket)
@@ -70,15 +75,18 @@ func NewClient(connectionMode ConnectionMode, configOption rpcinterface.ConfigOp
 	}
 	c.activeClient = activeClient
 
+	// Services may have been upgraded while the websocket was disconnected
+	c.activeClient.AddReconnectHandler(c.ClearVersionCache)
+
 	// Init Services
 	c.DaemonService = &DaemonService{client: c}
-	c.FullNodeService = &FullNodeService{client: c}
-	c.WalletService = &WalletService{client: c}
-	c.FarmerService = &FarmerService{client: c}
-	c.HarvesterService = &HarvesterService{client: c}
-	c.CrawlerService = &CrawlerService{client: c}
-	c.DataLayerService = &DataLayerService{client: c}
-	c.TimelordService = &TimelordService{client: c}
+	c.FullNodeService = &FullNodeService{SharedService: SharedService{client: c, service: rpcinterface.ServiceFullNode}}
+	c.WalletService = &WalletService{SharedService: SharedService{client: c, service: rpcinterface.ServiceWallet}}
+	c.FarmerService = &FarmerService{SharedService: SharedService{client: c, service: rpcinterface.ServiceFarmer}}
+	c.HarvesterService = &HarvesterService{SharedService: SharedService{client: c, service: rpcinterface.ServiceHarvester}}
+	c.CrawlerService = &CrawlerService{SharedService: SharedService{client: c, service: rpcinterface.ServiceCrawler}}
+	c.DataLayerService = &DataLayerService{SharedService: SharedService{client: c, service: rpcinterface.ServiceDataLayer}}
+	c.TimelordService = &TimelordService{SharedService: SharedService{client: c, service: rpcinterface.ServiceTimelord}}
 
 	return c, nil
 }
@@ -97,22 +105,58 @@ func (c *Client) Do(req *rpcinterface.Request, v rpcinterface.IResponse) (*http.
 	// resp will be nil in async websocket requests
 	// Any time we have a nil response, it's not a case of the RPC returning success: false, it's just a default value
 	if resp != nil && !v.IsSuccessful() {
-		return resp, &rpcinterface.ChiaRPCError{Message: v.GetRPCError()}
+		return resp, &rpcinterface.ChiaRPCError{
+			Service:    req.Service,
+			Endpoint:   req.Endpoint,
+			StatusCode: resp.StatusCode,
+			Message:    v.GetRPCError(),
+		}
 	}
 	return resp, nil
 }
 
 // Do Helper to create and send a new request for a given service and retain the proper types
-func Do[R rpcinterface.IResponse](service rpcinterface.Service, endpoint rpcinterface.Endpoint, opts any, v R) (R, *http.Response, error) {
+// Any request options (such as WithContext) are applied to the request before it is sent
+func Do[R rpcinterface.IResponse](service rpcinterface.Service, endpoint rpcinterface.Endpoint, opts any, v R, options ...rpcinterface.RequestOptionFunc) (R, *http.Response, error) {
 	req, err := service.NewRequest(endpoint, opts)
 	if err != nil {
 		return v, nil, err
 	}
 
+	for _, fn := range options {
+		if fn == nil {
+			continue
+		}
+		if err := fn(req); err != nil {
+			return v, nil, err
+		}
+	}
+
 	resp, err := service.GetClient().Do(req, v)
 	return v, resp, err
 }
 
+// InvalidateCache removes cached responses for the endpoints, or all cached responses if no endpoints are provided
+// Does nothing unless the cache is enabled in HTTP or public HTTP mode
+func (c *Client) InvalidateCache(endpoints ...rpcinterface.Endpoint) {
+	switch typed := c.activeClient.(type) {
+	case *httpclient.HTTPClient:
+		typed.InvalidateCache(endpoints...)
+	case *publichttpclient.HTTPClient:
+		typed.InvalidateCache(endpoints...)
+	}
+}
+
+// FullNodeStatuses returns the last known state of the nodes configured with WithFullNodes
+// Returns nil when not using failover full nodes in HTTP mode
+func (c *Client) FullNodeStatuses() []httpclient.NodeStatus {
+	typed, ok := c.activeClient.(*httpclient.HTTPClient)
+	if !ok {
+		return nil
+	}
+	return typed.FullNodeStatuses()
+}
+
 // Close calls the close method on the active client
 func (c *Client) Close() error {
 	return c.activeClient.Close()
@@ -130,6 +174,14 @@ func (c *Client) SetLogHandler(handler slog.Handler) {
 	c.activeClient.SetLogHandler(handler)
 }
 
+// AddInterceptor adds an interceptor that wraps every request made by the client
+// Does nothing if the active client doesn't implement rpcinterface.InterceptorClient
+func (c *Client) AddInterceptor(interceptor rpcinterface.Interceptor) {
+	if typed, ok := c.activeClient.(rpcinterface.InterceptorClient); ok {
+		typed.AddInterceptor(interceptor)
+	}
+}
+
 // SubscribeSelf subscribes to responses to requests from this service
 // This is currently only useful for websocket mode
 func (c *Client) SubscribeSelf() error {
@@ -90,6 +90,10 @@ func setup(t *testing.T) (*http.ServeMux, *httptest.Server, *Client) {
 				PortConfig: portConf,
 				SSL:        sslConf,
 			},
+			Timelord: config.TimelordConfig{
+				PortConfig: portConf,
+				SSL:        sslConf,
+			},
 		}))
 	if err != nil {
 		t.Fatal(err)
@@ -1,12 +1,18 @@
 package rpc
 
 import (
+	"context"
+	"crypto/tls"
 	"log/slog"
+	"net"
+	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/chia-network/go-chia-libs/pkg/config"
 	"github.com/chia-network/go-chia-libs/pkg/httpclient"
+	"github.com/chia-network/go-chia-libs/pkg/metrics"
+	"github.com/chia-network/go-chia-libs/pkg/publichttpclient"
 	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
 	"github.com/chia-network/go-chia-libs/pkg/websocketclient"
 )
@@ -47,7 +53,93 @@ func WithBaseURL(url *url.URL) rpcinterface.ClientOptionFunc {
 	}
 }
 
+// WithServiceURL sets the host for requests to a single service, for when services run on different machines
+// If the URL includes a port, it overrides the configured port for the service. Applies to the HTTP client
+func WithServiceURL(service rpcinterface.ServiceType, url *url.URL) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		typed, ok := c.(*httpclient.HTTPClient)
+		if ok {
+			return typed.SetServiceURL(service, url)
+		}
+		return nil
+	}
+}
+
+// WithServiceKeyPair sets the certificate and key used to connect to a single service
+// instead of the private key pair from the local config. Applies to the HTTP client
+func WithServiceKeyPair(service rpcinterface.ServiceType, keyPair *tls.Certificate) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		typed, ok := c.(*httpclient.HTTPClient)
+		if ok {
+			return typed.SetServiceKeyPair(service, keyPair)
+		}
+		return nil
+	}
+}
+
+// WithServiceSSLDir loads the private key pair for the services from a chia ssl directory, such as a copy of
+// CHIA_ROOT/config/ssl from the remote machine the services run on. The private_ca of that machine must have signed them
+// Applies to the HTTP client
+func WithServiceSSLDir(sslDir string, services ...rpcinterface.ServiceType) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		typed, ok := c.(*httpclient.HTTPClient)
+		if !ok {
+			return nil
+		}
+		for _, service := range services {
+			keyPair, err := httpclient.LoadServiceKeyPair(sslDir, service)
+			if err != nil {
+				return err
+			}
+			if err := typed.SetServiceKeyPair(service, keyPair); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// WithDialer sets the function used to open connections to chia services, such as UnixSocketDialer or an SSH tunnel
+// TLS is still negotiated over the returned connection. Applies to all clients
+func WithDialer(dial rpcinterface.DialContextFunc) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		switch typed := c.(type) {
+		case *httpclient.HTTPClient:
+			typed.SetDialer(dial)
+		case *publichttpclient.HTTPClient:
+			typed.SetDialer(dial)
+		case *websocketclient.WebsocketClient:
+			typed.SetDialer(dial)
+		}
+		return nil
+	}
+}
+
+// WithTransport sets the base http.RoundTripper used to send requests. Retries and cache still wrap the transport
+// An *http.Transport without a TLS config gets the client certificate for each service added automatically
+// Applies to the HTTP and public HTTP clients
+func WithTransport(transport http.RoundTripper) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		switch typed := c.(type) {
+		case *httpclient.HTTPClient:
+			typed.SetTransport(transport)
+		case *publichttpclient.HTTPClient:
+			typed.SetTransport(transport)
+		}
+		return nil
+	}
+}
+
+// UnixSocketDialer returns a dialer that connects to the unix socket at socketPath, regardless of the requested address
+func UnixSocketDialer(socketPath string) rpcinterface.DialContextFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", socketPath)
+	}
+}
+
 // WithCache specify a duration http requests should be cached for
+// Only the read endpoints in httpclient.DefaultCacheableEndpoints are cached, see httpclient.DefaultCachePolicy
 // If unset, cache will not be used
 func WithCache(validTime time.Duration) rpcinterface.ClientOptionFunc {
 	return func(c rpcinterface.Client) error {
@@ -60,6 +152,131 @@ func WithCache(validTime time.Duration) rpcinterface.ClientOptionFunc {
 	}
 }
 
+// WithCachePolicy caches responses from the endpoints allowed by the policy, each for its own TTL
+// Applies to the HTTP and public HTTP clients
+func WithCachePolicy(policy *httpclient.CachePolicy) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		switch typed := c.(type) {
+		case *httpclient.HTTPClient:
+			typed.SetCachePolicy(policy)
+		case *publichttpclient.HTTPClient:
+			typed.SetCachePolicy(policy)
+		}
+		return nil
+	}
+}
+
+// WithRetryPolicy retries requests that fail with connection errors or retryable status codes
+// Only read-only endpoints are retried by default, so mutating calls are never sent twice
+// Use httpclient.DefaultRetryPolicy() for sensible defaults. Applies to the HTTP and public HTTP clients
+func WithRetryPolicy(policy *httpclient.RetryPolicy) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		switch typed := c.(type) {
+		case *httpclient.HTTPClient:
+			typed.SetRetryPolicy(policy)
+		case *publichttpclient.HTTPClient:
+			typed.SetRetryPolicy(policy)
+		}
+		return nil
+	}
+}
+
+// WithRecorder records every request and response to fixture files in dir, stored as <dir>/<service>/<endpoint>.json
+// The fixtures can be replayed later with WithReplay. Applies to the HTTP and public HTTP clients
+func WithRecorder(dir string) rpcinterface.ClientOptionFunc {
+	return withFixtures(httpclient.FixtureModeRecord, dir)
+}
+
+// WithReplay responds to requests with the fixtures recorded by WithRecorder in dir, without connecting to any server
+// Requests are matched on service, endpoint and payload, and requests that were not recorded fail with httpclient.ErrNoFixture
+// Applies to the HTTP and public HTTP clients
+func WithReplay(dir string) rpcinterface.ClientOptionFunc {
+	return withFixtures(httpclient.FixtureModeReplay, dir)
+}
+
+// withFixtures sets the fixture mode for the HTTP clients
+func withFixtures(mode httpclient.FixtureMode, dir string) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		switch typed := c.(type) {
+		case *httpclient.HTTPClient:
+			typed.SetFixtures(mode, dir)
+		case *publichttpclient.HTTPClient:
+			typed.SetFixtures(mode, dir)
+		}
+		return nil
+	}
+}
+
+// WithRateLimit limits the rate of requests in public HTTP mode, globally and per endpoint
+// Requests throttled by the server are retried after the Retry-After wait even without this option, using
+// publichttpclient.DefaultRateLimitPolicy()
+func WithRateLimit(policy *publichttpclient.RateLimitPolicy) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		typed, ok := c.(*publichttpclient.HTTPClient)
+		if ok {
+			typed.SetRateLimitPolicy(policy)
+		}
+		return nil
+	}
+}
+
+// WithHeaders sets headers that are sent with every request in public HTTP mode, such as an API key
+// The headers are redacted like the other credentials and never reach logs, the cache or recorded fixtures
+func WithHeaders(headers http.Header) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		typed, ok := c.(*publichttpclient.HTTPClient)
+		if ok {
+			typed.SetHeaders(headers)
+		}
+		return nil
+	}
+}
+
+// WithBearerToken authenticates every request in public HTTP mode with the token in the Authorization header
+func WithBearerToken(token string) rpcinterface.ClientOptionFunc {
+	return WithCredentialProvider(publichttpclient.BearerToken(token))
+}
+
+// WithCredentialProvider authenticates every request in public HTTP mode with the credentials from the provider
+// Providers that implement publichttpclient.Refresher are refreshed when the server responds with 401 Unauthorized
+func WithCredentialProvider(provider publichttpclient.CredentialProvider) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		typed, ok := c.(*publichttpclient.HTTPClient)
+		if ok {
+			typed.SetCredentialProvider(provider)
+		}
+		return nil
+	}
+}
+
+// WithMaxResponseSize sets the largest response body, in bytes, that will be read in HTTP or public HTTP mode
+// Larger responses fail with an error matching httpclient.ErrResponseTooLarge instead of being read into memory
+func WithMaxResponseSize(maxBytes int64) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		switch typed := c.(type) {
+		case *httpclient.HTTPClient:
+			typed.SetMaxResponseSize(maxBytes)
+		case *publichttpclient.HTTPClient:
+			typed.SetMaxResponseSize(maxBytes)
+		}
+		return nil
+	}
+}
+
+// WithStreamingDecode decodes responses as they are read in HTTP or public HTTP mode, instead of reading the entire
+// body into memory first. The body of the returned http.Response is empty when streaming
+func WithStreamingDecode() rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		switch typed := c.(type) {
+		case *httpclient.HTTPClient:
+			typed.SetStreamingDecode(true)
+		case *publichttpclient.HTTPClient:
+			typed.SetStreamingDecode(true)
+		}
+		return nil
+	}
+}
+
 // WithTimeout sets the timeout for the requests
 func WithTimeout(timeout time.Duration) rpcinterface.ClientOptionFunc {
 	return func(c rpcinterface.Client) error {
@@ -80,3 +297,62 @@ func WithLogHandler(handler slog.Handler) rpcinterface.ClientOptionFunc {
 		return nil
 	}
 }
+
+// WithInterceptors adds interceptors that wrap every RPC request, regardless of connection mode
+// Interceptors are called in the order provided, so the first interceptor is the outermost
+// Applies to clients implementing rpcinterface.InterceptorClient, which includes all the clients in this library
+func WithInterceptors(interceptors ...rpcinterface.Interceptor) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		typed, ok := c.(rpcinterface.InterceptorClient)
+		if ok {
+			for _, interceptor := range interceptors {
+				typed.AddInterceptor(interceptor)
+			}
+		}
+		return nil
+	}
+}
+
+// WithFullNodes sends full node requests to the best of several full nodes instead of the base URL
+// Nodes are health checked with get_blockchain_state and synced nodes with the highest peak are preferred. Requests
+// fail over to the next best node when a node can't be reached. Nodes without a port use the configured full node port
+// Applies to the HTTP client
+func WithFullNodes(nodes ...*url.URL) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		typed, ok := c.(*httpclient.HTTPClient)
+		if ok {
+			typed.SetFullNodes(nodes)
+		}
+		return nil
+	}
+}
+
+// WithHealthCheckInterval sets how often the nodes passed to WithFullNodes are health checked
+func WithHealthCheckInterval(interval time.Duration) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		typed, ok := c.(*httpclient.HTTPClient)
+		if ok {
+			typed.SetHealthCheckInterval(interval)
+		}
+		return nil
+	}
+}
+
+// WithMetrics reports every RPC call to the recorder, along with cache hits and misses in HTTP modes, and reconnects and
+// the handler queue depth in websocket mode. Use metrics.NewPrometheus() to expose them in the Prometheus text format
+func WithMetrics(recorder metrics.Recorder) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		switch typed := c.(type) {
+		case *httpclient.HTTPClient:
+			typed.SetMetrics(recorder)
+		case *publichttpclient.HTTPClient:
+			typed.SetMetrics(recorder)
+		case *websocketclient.WebsocketClient:
+			typed.SetMetrics(recorder)
+		}
+		if typed, ok := c.(rpcinterface.InterceptorClient); ok {
+			typed.AddInterceptor(metrics.Interceptor(recorder))
+		}
+		return nil
+	}
+}
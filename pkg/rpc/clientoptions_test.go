@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	chiatls "github.com/chia-network/go-chia-libs/pkg/tls"
+)
+
+func TestWithServiceURLAndSSLDir(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true}`)
+	})
+
+	// A "remote" ssl dir with its own private CA
+	sslDir := t.TempDir()
+	caDER, caKey, err := chiatls.GenerateNewCA()
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+	walletDER, walletKey, err := chiatls.GenerateCASignedCert(caCert, caKey)
+	require.NoError(t, err)
+	require.NoError(t, os.Mkdir(filepath.Join(sslDir, "wallet"), 0700))
+	_, _, err = chiatls.WriteCertAndKey(walletDER, walletKey, filepath.Join(sslDir, "wallet", "private_wallet"))
+	require.NoError(t, err)
+	caPool := x509.NewCertPool()
+	caPool.AddCert(caCert)
+
+	// The remote wallet only accepts clients with a cert signed by its private CA
+	walletMux := http.NewServeMux()
+	walletMux.HandleFunc("/get_wallet_balance", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "wallet_balance": {"wallet_id": 1}}`)
+	})
+	wallet := httptest.NewUnstartedServer(walletMux)
+	wallet.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: caPool}
+	wallet.Config.ErrorLog = log.New(io.Discard, "", 0)
+	wallet.StartTLS()
+	defer wallet.Close()
+
+	walletURL, err := url.Parse(wallet.URL)
+	require.NoError(t, err)
+	require.NoError(t, WithServiceURL(rpcinterface.ServiceWallet, walletURL)(client.activeClient))
+
+	// Local certs are rejected by the remote wallet
+	_, _, err = client.WalletService.GetWalletBalance(&GetWalletBalanceOptions{WalletID: 1})
+	require.Error(t, err)
+
+	require.NoError(t, WithServiceSSLDir(sslDir, rpcinterface.ServiceWallet)(client.activeClient))
+	balance, _, err := client.WalletService.GetWalletBalance(&GetWalletBalanceOptions{WalletID: 1})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), balance.Balance.MustGet().WalletID)
+
+	// Other services still use the base URL and local certs
+	_, _, err = client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+
+	require.Error(t, WithServiceSSLDir(t.TempDir(), rpcinterface.ServiceFarmer)(client.activeClient))
+}
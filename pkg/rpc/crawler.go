@@ -1,6 +1,8 @@
 package rpc
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	"github.com/samber/mo"
@@ -11,27 +13,7 @@ import (
 
 // CrawlerService encapsulates crawler RPC methods
 type CrawlerService struct {
-	client *Client
-}
-
-// NewRequest returns a new request specific to the crawler service
-func (s *CrawlerService) NewRequest(rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
-	return s.client.NewRequest(rpcinterface.ServiceCrawler, rpcEndpoint, opt)
-}
-
-// GetClient returns the active client for the service
-func (s *CrawlerService) GetClient() rpcinterface.Client {
-	return s.client
-}
-
-// GetNetworkInfo gets the network name and prefix from the full node
-func (s *CrawlerService) GetNetworkInfo(opts *GetNetworkInfoOptions) (*GetNetworkInfoResponse, *http.Response, error) {
-	return Do(s, "get_network_info", opts, &GetNetworkInfoResponse{})
-}
-
-// GetVersion returns the application version for the service
-func (s *CrawlerService) GetVersion(opts *GetVersionOptions) (*GetVersionResponse, *http.Response, error) {
-	return Do(s, "get_version", opts, &GetVersionResponse{})
+	SharedService
 }
 
 // GetPeerCountsResponse Response for get_get_peer_counts on crawler
@@ -41,8 +23,8 @@ type GetPeerCountsResponse struct {
 }
 
 // GetPeerCounts crawler rpc -> get_peer_counts
-func (s *CrawlerService) GetPeerCounts() (*GetPeerCountsResponse, *http.Response, error) {
-	return Do(s, "get_peer_counts", nil, &GetPeerCountsResponse{})
+func (s *CrawlerService) GetPeerCounts(options ...rpcinterface.RequestOptionFunc) (*GetPeerCountsResponse, *http.Response, error) {
+	return Do(s, "get_peer_counts", nil, &GetPeerCountsResponse{}, options...)
 }
 
 // GetIPsAfterTimestampOptions Options for the get_ips_after_timestamp RPC call
@@ -60,6 +42,49 @@ type GetIPsAfterTimestampResponse struct {
 }
 
 // GetIPsAfterTimestamp Returns IP addresses seen by the network after a particular timestamp
-func (s *CrawlerService) GetIPsAfterTimestamp(opts *GetIPsAfterTimestampOptions) (*GetIPsAfterTimestampResponse, *http.Response, error) {
-	return Do(s, "get_ips_after_timestamp", opts, &GetIPsAfterTimestampResponse{})
+func (s *CrawlerService) GetIPsAfterTimestamp(opts *GetIPsAfterTimestampOptions, options ...rpcinterface.RequestOptionFunc) (*GetIPsAfterTimestampResponse, *http.Response, error) {
+	return Do(s, "get_ips_after_timestamp", opts, &GetIPsAfterTimestampResponse{}, options...)
+}
+
+const (
+	// DefaultIPsPageSize is the number of IPs requested per get_ips_after_timestamp call when iterating
+	DefaultIPsPageSize = 1000
+
+	// MaxIPsPageSize is the most IPs requested per get_ips_after_timestamp call when iterating
+	MaxIPsPageSize = 10000
+)
+
+// IterateIPsAfterTimestampOptions options for IterateIPsAfterTimestamp
+type IterateIPsAfterTimestampOptions struct {
+	After int64
+	// PageSize is the number of IPs to request per call. Capped at MaxIPsPageSize
+	PageSize int
+}
+
+// IterateIPsAfterTimestamp returns an iterator over all IPs seen after the timestamp, calling get_ips_after_timestamp one page at a time
+func (s *CrawlerService) IterateIPsAfterTimestamp(opts *IterateIPsAfterTimestampOptions, options ...rpcinterface.RequestOptionFunc) *Iterator[string] {
+	if opts == nil {
+		return errorIterator[string](errors.New("IterateIPsAfterTimestamp requires options with the timestamp"))
+	}
+	pageSize := clampPageSize(opts.PageSize, DefaultIPsPageSize, MaxIPsPageSize)
+	var offset uint
+
+	return NewIterator(func(_ context.Context, options ...rpcinterface.RequestOptionFunc) ([]string, bool, error) {
+		ips, _, err := s.GetIPsAfterTimestamp(&GetIPsAfterTimestampOptions{
+			After:  opts.After,
+			Offset: offset,
+			Limit:  uint(pageSize),
+		}, options...)
+		if err != nil {
+			return nil, false, err
+		}
+
+		page := ips.IPs.OrEmpty()
+		offset += uint(len(page))
+		done := len(page) < pageSize
+		if total, ok := ips.Total.Get(); ok && int(offset) >= total {
+			done = true
+		}
+		return page, done, nil
+	}, options...)
 }
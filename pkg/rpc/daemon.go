@@ -23,13 +23,13 @@ func (s *DaemonService) GetClient() rpcinterface.Client {
 }
 
 // GetNetworkInfo gets the network name and prefix from the full node
-func (s *DaemonService) GetNetworkInfo(opts *GetNetworkInfoOptions) (*GetNetworkInfoResponse, *http.Response, error) {
-	return Do(s, "get_network_info", opts, &GetNetworkInfoResponse{})
+func (s *DaemonService) GetNetworkInfo(opts *GetNetworkInfoOptions, options ...rpcinterface.RequestOptionFunc) (*GetNetworkInfoResponse, *http.Response, error) {
+	return Do(s, "get_network_info", opts, &GetNetworkInfoResponse{}, options...)
 }
 
 // GetVersion returns the application version for the service
-func (s *DaemonService) GetVersion(opts *GetVersionOptions) (*GetVersionResponse, *http.Response, error) {
-	return Do(s, "get_version", opts, &GetVersionResponse{})
+func (s *DaemonService) GetVersion(opts *GetVersionOptions, options ...rpcinterface.RequestOptionFunc) (*GetVersionResponse, *http.Response, error) {
+	return Do(s, "get_version", opts, &GetVersionResponse{}, options...)
 }
 
 // GetKeysOptions configures how keys are returned in get_keys
@@ -44,8 +44,8 @@ type GetKeysResponse struct {
 }
 
 // GetKeys returns key information
-func (s *DaemonService) GetKeys(opts *GetKeysOptions) (*GetKeysResponse, *http.Response, error) {
-	return Do(s, "get_keys", opts, &GetKeysResponse{})
+func (s *DaemonService) GetKeys(opts *GetKeysOptions, options ...rpcinterface.RequestOptionFunc) (*GetKeysResponse, *http.Response, error) {
+	return Do(s, "get_keys", opts, &GetKeysResponse{}, options...)
 }
 
 // StartServiceOptions start service options
@@ -60,8 +60,8 @@ type StartServiceResponse struct {
 }
 
 // StartService starts the given service
-func (s *DaemonService) StartService(opts *StartServiceOptions) (*StartServiceResponse, *http.Response, error) {
-	return Do(s, "start_service", opts, &StartServiceResponse{})
+func (s *DaemonService) StartService(opts *StartServiceOptions, options ...rpcinterface.RequestOptionFunc) (*StartServiceResponse, *http.Response, error) {
+	return Do(s, "start_service", opts, &StartServiceResponse{}, options...)
 }
 
 // StopServiceOptions start service options
@@ -76,8 +76,8 @@ type StopServiceResponse struct {
 }
 
 // StopService stops the given service
-func (s *DaemonService) StopService(opts *StopServiceOptions) (*StopServiceResponse, *http.Response, error) {
-	return Do(s, "stop_service", opts, &StopServiceResponse{})
+func (s *DaemonService) StopService(opts *StopServiceOptions, options ...rpcinterface.RequestOptionFunc) (*StopServiceResponse, *http.Response, error) {
+	return Do(s, "stop_service", opts, &StopServiceResponse{}, options...)
 }
 
 // IsRunningOptions is service running options
@@ -93,8 +93,8 @@ type IsRunningResponse struct {
 }
 
 // IsRunning returns whether a service is running
-func (s *DaemonService) IsRunning(opts *IsRunningOptions) (*IsRunningResponse, *http.Response, error) {
-	return Do(s, "is_running", opts, &IsRunningResponse{})
+func (s *DaemonService) IsRunning(opts *IsRunningOptions, options ...rpcinterface.RequestOptionFunc) (*IsRunningResponse, *http.Response, error) {
+	return Do(s, "is_running", opts, &IsRunningResponse{}, options...)
 }
 
 // RunningServicesResponse is service running response
@@ -104,8 +104,8 @@ type RunningServicesResponse struct {
 }
 
 // RunningServices returns all running services
-func (s *DaemonService) RunningServices() (*RunningServicesResponse, *http.Response, error) {
-	return Do(s, "running_services", nil, &RunningServicesResponse{})
+func (s *DaemonService) RunningServices(options ...rpcinterface.RequestOptionFunc) (*RunningServicesResponse, *http.Response, error) {
+	return Do(s, "running_services", nil, &RunningServicesResponse{}, options...)
 }
 
 // ExitResponse shows information about the services that were stopped
@@ -115,8 +115,8 @@ type ExitResponse struct {
 }
 
 // Exit tells the daemon to exit
-func (s *DaemonService) Exit() (*ExitResponse, *http.Response, error) {
-	return Do(s, "exit", nil, &ExitResponse{})
+func (s *DaemonService) Exit(options ...rpcinterface.RequestOptionFunc) (*ExitResponse, *http.Response, error) {
+	return Do(s, "exit", nil, &ExitResponse{}, options...)
 }
 
 // DaemonDeleteAllKeysOpts options for delete all keys request
@@ -128,6 +128,6 @@ type DaemonDeleteAllKeysResponse struct {
 }
 
 // DeleteAllKeys deletes all keys from the keychain
-func (s *DaemonService) DeleteAllKeys(opts *DaemonDeleteAllKeysOpts) (*DaemonDeleteAllKeysResponse, *http.Response, error) {
-	return Do(s, "delete_all_keys", opts, &DaemonDeleteAllKeysResponse{})
+func (s *DaemonService) DeleteAllKeys(opts *DaemonDeleteAllKeysOpts, options ...rpcinterface.RequestOptionFunc) (*DaemonDeleteAllKeysResponse, *http.Response, error) {
+	return Do(s, "delete_all_keys", opts, &DaemonDeleteAllKeysResponse{}, options...)
 }
@@ -1,35 +1,19 @@
 package rpc
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
+	"github.com/samber/mo"
+
 	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
 	"github.com/chia-network/go-chia-libs/pkg/types"
 )
 
 // DataLayerService encapsulates data layer RPC methods
 type DataLayerService struct {
-	client *Client
-}
-
-// NewRequest returns a new request specific to the wallet service
-func (s *DataLayerService) NewRequest(rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
-	return s.client.NewRequest(rpcinterface.ServiceDataLayer, rpcEndpoint, opt)
-}
-
-// GetClient returns the active client for the service
-func (s *DataLayerService) GetClient() rpcinterface.Client {
-	return s.client
-}
-
-// GetNetworkInfo gets the network name and prefix from the full node
-func (s *DataLayerService) GetNetworkInfo(opts *GetNetworkInfoOptions) (*GetNetworkInfoResponse, *http.Response, error) {
-	return Do(s, "get_network_info", opts, &GetNetworkInfoResponse{})
-}
-
-// GetVersion returns the application version for the service
-func (s *DataLayerService) GetVersion(opts *GetVersionOptions) (*GetVersionResponse, *http.Response, error) {
-	return Do(s, "get_version", opts, &GetVersionResponse{})
+	SharedService
 }
 
 // DatalayerGetSubscriptionsOptions options for get_subscriptions
@@ -43,13 +27,13 @@ type DatalayerGetSubscriptionsResponse struct {
 
 // GetSubscriptions is just an alias for Subscriptions, since the CLI command is get_subscriptions
 // Makes this easier to find
-func (s *DataLayerService) GetSubscriptions(opts *DatalayerGetSubscriptionsOptions) (*DatalayerGetSubscriptionsResponse, *http.Response, error) {
-	return s.Subscriptions(opts)
+func (s *DataLayerService) GetSubscriptions(opts *DatalayerGetSubscriptionsOptions, options ...rpcinterface.RequestOptionFunc) (*DatalayerGetSubscriptionsResponse, *http.Response, error) {
+	return s.Subscriptions(opts, options...)
 }
 
 // Subscriptions calls the subscriptions endpoint to list all subscriptions
-func (s *DataLayerService) Subscriptions(opts *DatalayerGetSubscriptionsOptions) (*DatalayerGetSubscriptionsResponse, *http.Response, error) {
-	return Do(s, "subscriptions", opts, &DatalayerGetSubscriptionsResponse{})
+func (s *DataLayerService) Subscriptions(opts *DatalayerGetSubscriptionsOptions, options ...rpcinterface.RequestOptionFunc) (*DatalayerGetSubscriptionsResponse, *http.Response, error) {
+	return Do(s, "subscriptions", opts, &DatalayerGetSubscriptionsResponse{}, options...)
 }
 
 // DatalayerGetOwnedStoresOptions Options for get_owned_stores
@@ -62,8 +46,8 @@ type DatalayerGetOwnedStoresResponse struct {
 }
 
 // GetOwnedStores RPC endpoint get_owned_stores
-func (s *DataLayerService) GetOwnedStores(opts *DatalayerGetOwnedStoresOptions) (*DatalayerGetOwnedStoresResponse, *http.Response, error) {
-	return Do(s, "get_owned_stores", opts, &DatalayerGetOwnedStoresResponse{})
+func (s *DataLayerService) GetOwnedStores(opts *DatalayerGetOwnedStoresOptions, options ...rpcinterface.RequestOptionFunc) (*DatalayerGetOwnedStoresResponse, *http.Response, error) {
+	return Do(s, "get_owned_stores", opts, &DatalayerGetOwnedStoresResponse{}, options...)
 }
 
 // DatalayerGetMirrorsOptions Options for get_mirrors
@@ -78,8 +62,8 @@ type DatalayerGetMirrorsResponse struct {
 }
 
 // GetMirrors lists the mirrors for the given datalayer store
-func (s *DataLayerService) GetMirrors(opts *DatalayerGetMirrorsOptions) (*DatalayerGetMirrorsResponse, *http.Response, error) {
-	return Do(s, "get_mirrors", opts, &DatalayerGetMirrorsResponse{})
+func (s *DataLayerService) GetMirrors(opts *DatalayerGetMirrorsOptions, options ...rpcinterface.RequestOptionFunc) (*DatalayerGetMirrorsResponse, *http.Response, error) {
+	return Do(s, "get_mirrors", opts, &DatalayerGetMirrorsResponse{}, options...)
 }
 
 // DatalayerDeleteMirrorOptions options for delete_mirror RPC call
@@ -94,8 +78,8 @@ type DatalayerDeleteMirrorResponse struct {
 }
 
 // DeleteMirror deletes a datalayer mirror
-func (s *DataLayerService) DeleteMirror(opts *DatalayerDeleteMirrorOptions) (*DatalayerDeleteMirrorResponse, *http.Response, error) {
-	return Do(s, "delete_mirror", opts, &DatalayerDeleteMirrorResponse{})
+func (s *DataLayerService) DeleteMirror(opts *DatalayerDeleteMirrorOptions, options ...rpcinterface.RequestOptionFunc) (*DatalayerDeleteMirrorResponse, *http.Response, error) {
+	return Do(s, "delete_mirror", opts, &DatalayerDeleteMirrorResponse{}, options...)
 }
 
 // DatalayerAddMirrorOptions options for delete_mirror RPC call
@@ -112,8 +96,8 @@ type DatalayerAddMirrorResponse struct {
 }
 
 // AddMirror deletes a datalayer mirror
-func (s *DataLayerService) AddMirror(opts *DatalayerAddMirrorOptions) (*DatalayerAddMirrorResponse, *http.Response, error) {
-	return Do(s, "add_mirror", opts, &DatalayerAddMirrorResponse{})
+func (s *DataLayerService) AddMirror(opts *DatalayerAddMirrorOptions, options ...rpcinterface.RequestOptionFunc) (*DatalayerAddMirrorResponse, *http.Response, error) {
+	return Do(s, "add_mirror", opts, &DatalayerAddMirrorResponse{}, options...)
 }
 
 // DatalayerSubscribeOptions options for subscribe
@@ -128,8 +112,8 @@ type DatalayerSubscribeResponse struct {
 }
 
 // Subscribe deletes a datalayer mirror
-func (s *DataLayerService) Subscribe(opts *DatalayerSubscribeOptions) (*DatalayerSubscribeResponse, *http.Response, error) {
-	return Do(s, "subscribe", opts, &DatalayerSubscribeResponse{})
+func (s *DataLayerService) Subscribe(opts *DatalayerSubscribeOptions, options ...rpcinterface.RequestOptionFunc) (*DatalayerSubscribeResponse, *http.Response, error) {
+	return Do(s, "subscribe", opts, &DatalayerSubscribeResponse{}, options...)
 }
 
 // DatalayerUnsubscribeOptions options for unsubscribing to a datastore
@@ -144,22 +128,67 @@ type DatalayerUnsubscribeResponse struct {
 }
 
 // Unsubscribe deletes a datalayer mirror
-func (s *DataLayerService) Unsubscribe(opts *DatalayerUnsubscribeOptions) (*DatalayerUnsubscribeResponse, *http.Response, error) {
-	return Do(s, "unsubscribe", opts, &DatalayerUnsubscribeResponse{})
+func (s *DataLayerService) Unsubscribe(opts *DatalayerUnsubscribeOptions, options ...rpcinterface.RequestOptionFunc) (*DatalayerUnsubscribeResponse, *http.Response, error) {
+	return Do(s, "unsubscribe", opts, &DatalayerUnsubscribeResponse{}, options...)
 }
 
 // DatalayerGetKeysValuesOptions options for get_keys_values
 type DatalayerGetKeysValuesOptions struct {
-	ID string `json:"id"` // Hex String
+	ID       string `json:"id"`                  // Hex String
+	RootHash string `json:"root_hash,omitempty"` // Hex String
+	// Page requests a single page of results (0 indexed). When nil, the whole store is returned at once
+	Page *int `json:"page,omitempty"`
+	// MaxPageSize is the maximum size of a page in bytes. Only applies when Page is set
+	MaxPageSize *int `json:"max_page_size,omitempty"`
 }
 
 // DatalayerGetKeysValuesResponse represents the response from the get_keys_values RPC endpoint
 type DatalayerGetKeysValuesResponse struct {
 	rpcinterface.Response
 	KeysValues []types.DatalayerKeyValue `json:"keys_values"`
+	// TotalPages and TotalBytes are only present for paginated requests
+	TotalPages mo.Option[int] `json:"total_pages"`
+	TotalBytes mo.Option[int] `json:"total_bytes"`
 }
 
 // GetKeysValues retrieves all keys and values for a given datalayer store
-func (s *DataLayerService) GetKeysValues(opts *DatalayerGetKeysValuesOptions) (*DatalayerGetKeysValuesResponse, *http.Response, error) {
-	return Do(s, "get_keys_values", opts, &DatalayerGetKeysValuesResponse{})
+func (s *DataLayerService) GetKeysValues(opts *DatalayerGetKeysValuesOptions, options ...rpcinterface.RequestOptionFunc) (*DatalayerGetKeysValuesResponse, *http.Response, error) {
+	return Do(s, "get_keys_values", opts, &DatalayerGetKeysValuesResponse{}, options...)
+}
+
+// IterateKeysValuesOptions options for IterateKeysValues
+type IterateKeysValuesOptions struct {
+	ID       string // Hex String
+	RootHash string // Hex String
+	// MaxPageSize is the maximum size of a page in bytes. Uses the server default when zero
+	MaxPageSize int
+}
+
+// IterateKeysValues returns an iterator over all keys and values in a store, calling get_keys_values one page at a time
+// Pages are sized by the server, which limits each page to MaxPageSize bytes
+func (s *DataLayerService) IterateKeysValues(opts *IterateKeysValuesOptions, options ...rpcinterface.RequestOptionFunc) *Iterator[types.DatalayerKeyValue] {
+	if opts == nil {
+		return errorIterator[types.DatalayerKeyValue](errors.New("IterateKeysValues requires options with the store ID"))
+	}
+	page := 0
+
+	return NewIterator(func(_ context.Context, options ...rpcinterface.RequestOptionFunc) ([]types.DatalayerKeyValue, bool, error) {
+		current := page
+		request := &DatalayerGetKeysValuesOptions{
+			ID:       opts.ID,
+			RootHash: opts.RootHash,
+			Page:     &current,
+		}
+		if opts.MaxPageSize > 0 {
+			request.MaxPageSize = &opts.MaxPageSize
+		}
+		keysValues, _, err := s.GetKeysValues(request, options...)
+		if err != nil {
+			return nil, false, err
+		}
+
+		page++
+		// Servers without pagination support return everything in a single response without total_pages
+		return keysValues.KeysValues, page >= keysValues.TotalPages.OrElse(0), nil
+	}, options...)
 }
@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/httpclient"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+func TestWithMaxResponseSize(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	require.NoError(t, WithMaxResponseSize(1024)(client.activeClient))
+
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"success": true, "blockchain_state": {"peak": {"height": 5}}}`)
+	})
+	mux.HandleFunc("/get_network_info", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"success": true, "network_name": "%s"}`, strings.Repeat("a", 2048))
+	})
+	mux.HandleFunc("/get_block", func(w http.ResponseWriter, r *http.Request) {
+		// Flushing before the body is written leaves out Content-Length, so the size is only known while reading
+		_, _ = fmt.Fprint(w, `{"success": true, "block": {"header_hash": "`)
+		w.(http.Flusher).Flush()
+		_, _ = fmt.Fprintf(w, `%s"}}`, strings.Repeat("a", 2048))
+	})
+
+	state, resp, err := client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, uint32(5), state.BlockchainState.MustGet().Peak.MustGet().Height)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NotEmpty(t, body)
+
+	// Content-Length is over the limit, so the body isn't read
+	_, _, err = client.FullNodeService.GetNetworkInfo(&GetNetworkInfoOptions{})
+	require.ErrorIs(t, err, httpclient.ErrResponseTooLarge)
+
+	_, _, err = client.FullNodeService.GetBlock(&GetBlockOptions{})
+	require.ErrorIs(t, err, httpclient.ErrResponseTooLarge)
+
+	require.NoError(t, WithStreamingDecode()(client.activeClient))
+
+	state, resp, err = client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, uint32(5), state.BlockchainState.MustGet().Peak.MustGet().Height)
+	require.Equal(t, http.NoBody, resp.Body)
+
+	_, _, err = client.FullNodeService.GetBlock(&GetBlockOptions{})
+	require.ErrorIs(t, err, httpclient.ErrResponseTooLarge)
+}
+
+func TestMaxResponseSizeNotCachedOrRecorded(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	fixtureDir := t.TempDir()
+	require.NoError(t, WithMaxResponseSize(1024)(client.activeClient))
+	require.NoError(t, WithRecorder(fixtureDir)(client.activeClient))
+	require.NoError(t, WithCachePolicy(&httpclient.CachePolicy{
+		TTLs: map[rpcinterface.Endpoint]time.Duration{
+			"get_network_info": time.Minute,
+			"get_block":        time.Minute,
+		},
+	})(client.activeClient))
+
+	var infoCalls, blockCalls atomic.Int32
+	mux.HandleFunc("/get_network_info", func(w http.ResponseWriter, r *http.Request) {
+		infoCalls.Add(1)
+		_, _ = fmt.Fprintf(w, `{"success": true, "network_name": "%s"}`, strings.Repeat("a", 2048))
+	})
+	mux.HandleFunc("/get_block", func(w http.ResponseWriter, r *http.Request) {
+		blockCalls.Add(1)
+		_, _ = fmt.Fprint(w, `{"success": true, "block": {"header_hash": "`)
+		w.(http.Flusher).Flush()
+		_, _ = fmt.Fprintf(w, `%s"}}`, strings.Repeat("a", 2048))
+	})
+
+	for i := 0; i < 2; i++ {
+		_, _, err := client.FullNodeService.GetNetworkInfo(&GetNetworkInfoOptions{})
+		require.ErrorIs(t, err, httpclient.ErrResponseTooLarge)
+		_, _, err = client.FullNodeService.GetBlock(&GetBlockOptions{})
+		require.ErrorIs(t, err, httpclient.ErrResponseTooLarge)
+	}
+
+	// Neither response was cached, so both requests reached the server again
+	require.Equal(t, int32(2), infoCalls.Load())
+	require.Equal(t, int32(2), blockCalls.Load())
+	require.NoFileExists(t, filepath.Join(fixtureDir, "full_node", "get_network_info.json"))
+	require.NoFileExists(t, filepath.Join(fixtureDir, "full_node", "get_block.json"))
+}
@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pipeListener is a net.Listener for in-memory connections created with net.Pipe
+type pipeListener struct {
+	conns  chan net.Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newPipeListener() *pipeListener {
+	return &pipeListener{conns: make(chan net.Conn), closed: make(chan struct{})}
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *pipeListener) Close() error {
+	l.once.Do(func() { close(l.closed) })
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr {
+	return &net.UnixAddr{Name: "pipe", Net: "pipe"}
+}
+
+func (l *pipeListener) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	client, server := net.Pipe()
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestWithDialer(t *testing.T) {
+	_, server, client := setup(t)
+	defer teardown(server)
+
+	listener := newPipeListener()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "blockchain_state": {"difficulty": 42}}`)
+	})
+	pipeServer := httptest.NewUnstartedServer(mux)
+	pipeServer.Listener = listener
+	pipeServer.StartTLS()
+	defer pipeServer.Close()
+
+	// The host doesn't resolve, so the request can only succeed over the pipe
+	require.NoError(t, WithBaseURL(&url.URL{Scheme: "https", Host: "node.invalid"})(client.activeClient))
+	require.NoError(t, WithDialer(listener.DialContext)(client.activeClient))
+
+	state, _, err := client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), state.BlockchainState.MustGet().Difficulty)
+}
+
+func TestUnixSocketDialer(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "rpc.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "blockchain_state": {"difficulty": 7}}`)
+	})
+	unixServer := httptest.NewUnstartedServer(mux)
+	_ = unixServer.Listener.Close()
+	unixServer.Listener = listener
+	unixServer.Start()
+	defer unixServer.Close()
+
+	client, err := NewClient(ConnectionModePublicHTTP, WithPublicConfig(),
+		WithBaseURL(&url.URL{Scheme: "http", Host: "localhost"}),
+		WithDialer(UnixSocketDialer(socketPath)),
+	)
+	require.NoError(t, err)
+
+	state, _, err := client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), state.BlockchainState.MustGet().Difficulty)
+}
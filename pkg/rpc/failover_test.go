@@ -0,0 +1,165 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/httpclient"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+func TestWithFullNodes(t *testing.T) {
+	_, server, client := setup(t)
+	defer teardown(server)
+
+	// Each node reports its sync state and counts the other requests it serves
+	node := func(synced bool, height uint32, served *atomic.Int32) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"success": true, "blockchain_state": {"peak": {"height": %d}, "sync": {"synced": %t}}}`, height, synced)
+		})
+		mux.HandleFunc("/get_network_info", func(w http.ResponseWriter, r *http.Request) {
+			served.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"success": true, "network_name": "mainnet", "network_prefix": "xch"}`)
+		})
+		return httptest.NewTLSServer(mux)
+	}
+
+	var deadServed, syncingServed, bestServed, behindServed atomic.Int32
+	dead := node(true, 300, &deadServed)
+	dead.Close()
+	syncing := node(false, 250, &syncingServed)
+	defer syncing.Close()
+	best := node(true, 200, &bestServed)
+	behind := node(true, 150, &behindServed)
+	defer behind.Close()
+
+	nodes := []*url.URL{}
+	for _, s := range []*httptest.Server{dead, syncing, best, behind} {
+		u, err := url.Parse(s.URL)
+		require.NoError(t, err)
+		nodes = append(nodes, u)
+	}
+	require.NoError(t, WithFullNodes(nodes...)(client.activeClient))
+	require.NoError(t, WithHealthCheckInterval(time.Hour)(client.activeClient))
+
+	// Synced node with the highest peak is preferred
+	_, _, err := client.FullNodeService.GetNetworkInfo(&GetNetworkInfoOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), bestServed.Load())
+
+	// Fails over to the next synced node when the best node goes away
+	best.Close()
+	_, _, err = client.FullNodeService.GetNetworkInfo(&GetNetworkInfoOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), behindServed.Load())
+	require.Equal(t, int32(0), deadServed.Load()+syncingServed.Load())
+
+	statuses := client.FullNodeStatuses()
+	require.Len(t, statuses, 4)
+	require.False(t, statuses[0].Healthy)
+	require.True(t, statuses[1].Healthy)
+	require.False(t, statuses[1].Synced)
+	require.False(t, statuses[2].Healthy)
+	require.True(t, statuses[3].Healthy)
+	require.Equal(t, uint32(150), statuses[3].PeakHeight)
+}
+
+func TestWithFullNodesProbes(t *testing.T) {
+	_, server, client := setup(t)
+	defer teardown(server)
+
+	var probes atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "blockchain_state": {"peak": {"height": 1}, "sync": {"synced": true}}}`)
+	})
+	mux.HandleFunc("/get_network_info", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "network_name": "mainnet", "network_prefix": "xch"}`)
+	})
+	node := httptest.NewTLSServer(mux)
+	defer node.Close()
+	nodeURL, err := url.Parse(node.URL)
+	require.NoError(t, err)
+
+	// The port is set after the node without a port, and is still used for the node
+	require.NoError(t, WithCachePolicy(&httpclient.CachePolicy{
+		TTLs: map[rpcinterface.Endpoint]time.Duration{"get_blockchain_state": time.Minute},
+	})(client.activeClient))
+	require.NoError(t, WithFullNodes(&url.URL{Scheme: "https", Host: nodeURL.Hostname()})(client.activeClient))
+	require.NoError(t, WithServiceURL(rpcinterface.ServiceFullNode, nodeURL)(client.activeClient))
+	require.NoError(t, WithHealthCheckInterval(time.Nanosecond)(client.activeClient))
+
+	// Health checks aren't served from the cache
+	for i := 0; i < 2; i++ {
+		_, _, err = client.FullNodeService.GetNetworkInfo(&GetNetworkInfoOptions{})
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(2), probes.Load())
+
+	statuses := client.FullNodeStatuses()
+	require.Len(t, statuses, 1)
+	require.Equal(t, nodeURL.Host, statuses[0].URL.Host)
+	require.True(t, statuses[0].Healthy)
+}
+
+func TestWithFullNodesRetriesAcrossNodes(t *testing.T) {
+	_, server, client := setup(t)
+	defer teardown(server)
+
+	node := func(height uint32, served *atomic.Int32) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"success": true, "blockchain_state": {"peak": {"height": %d}, "sync": {"synced": true}}}`, height)
+		})
+		mux.HandleFunc("/get_network_info", func(w http.ResponseWriter, r *http.Request) {
+			served.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"success": true, "network_name": "mainnet", "network_prefix": "xch"}`)
+		})
+		return httptest.NewTLSServer(mux)
+	}
+
+	var primaryServed, secondaryServed atomic.Int32
+	primary := node(200, &primaryServed)
+	secondary := node(150, &secondaryServed)
+	defer secondary.Close()
+
+	nodes := []*url.URL{}
+	for _, s := range []*httptest.Server{primary, secondary} {
+		u, err := url.Parse(s.URL)
+		require.NoError(t, err)
+		nodes = append(nodes, u)
+	}
+	policy := httpclient.DefaultRetryPolicy()
+	policy.InitialBackoff = 10 * time.Second
+	policy.MaxBackoff = 10 * time.Second
+	require.NoError(t, WithRetryPolicy(policy)(client.activeClient))
+	require.NoError(t, WithFullNodes(nodes...)(client.activeClient))
+	require.NoError(t, WithHealthCheckInterval(time.Hour)(client.activeClient))
+
+	_, _, err := client.FullNodeService.GetNetworkInfo(&GetNetworkInfoOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), primaryServed.Load())
+
+	// A dead primary fails over to the next node right away, instead of using up the retries and backoff on it first
+	primary.Close()
+	start := time.Now()
+	_, _, err = client.FullNodeService.GetNetworkInfo(&GetNetworkInfoOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), secondaryServed.Load())
+	require.Less(t, time.Since(start), 5*time.Second)
+}
@@ -12,32 +12,7 @@ import (
 
 // FarmerService encapsulates farmer RPC methods
 type FarmerService struct {
-	client *Client
-}
-
-// NewRequest returns a new request specific to the wallet service
-func (s *FarmerService) NewRequest(rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
-	return s.client.NewRequest(rpcinterface.ServiceFarmer, rpcEndpoint, opt)
-}
-
-// GetClient returns the active client for the service
-func (s *FarmerService) GetClient() rpcinterface.Client {
-	return s.client
-}
-
-// GetConnections returns connections
-func (s *FarmerService) GetConnections(opts *GetConnectionsOptions) (*GetConnectionsResponse, *http.Response, error) {
-	return Do(s, "get_connections", opts, &GetConnectionsResponse{})
-}
-
-// GetNetworkInfo gets the network name and prefix from the farmer
-func (s *FarmerService) GetNetworkInfo(opts *GetNetworkInfoOptions) (*GetNetworkInfoResponse, *http.Response, error) {
-	return Do(s, "get_network_info", opts, &GetNetworkInfoResponse{})
-}
-
-// GetVersion returns the application version for the service
-func (s *FarmerService) GetVersion(opts *GetVersionOptions) (*GetVersionResponse, *http.Response, error) {
-	return Do(s, "get_version", opts, &GetVersionResponse{})
+	SharedService
 }
 
 // FarmerGetHarvestersOptions optoins for get_harvesters endpoint. Currently, accepts no options
@@ -72,6 +47,6 @@ type FarmerGetHarvestersResponse struct {
 }
 
 // GetHarvesters returns all harvester details for the farmer
-func (s *FarmerService) GetHarvesters(opts *FarmerGetHarvestersOptions) (*FarmerGetHarvestersResponse, *http.Response, error) {
-	return Do(s, "get_harvesters", opts, &FarmerGetHarvestersResponse{})
+func (s *FarmerService) GetHarvesters(opts *FarmerGetHarvestersOptions, options ...rpcinterface.RequestOptionFunc) (*FarmerGetHarvestersResponse, *http.Response, error) {
+	return Do(s, "get_harvesters", opts, &FarmerGetHarvestersResponse{}, options...)
 }
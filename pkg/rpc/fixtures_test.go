@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/config"
+	"github.com/chia-network/go-chia-libs/pkg/httpclient"
+)
+
+func TestWithRecorderAndReplay(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	fixtureDir := t.TempDir()
+	require.NoError(t, WithRecorder(fixtureDir)(client.activeClient))
+
+	mux.HandleFunc("/get_wallet_balance", func(w http.ResponseWriter, r *http.Request) {
+		opts := &GetWalletBalanceOptions{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(opts))
+		w.Header().Set("Content-Type", "application/json")
+		if opts.WalletID > 1 {
+			_, _ = fmt.Fprint(w, `{"success": false, "error": "wallet id 2 does not exist"}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"success": true, "wallet_balance": {"wallet_id": %d, "confirmed_wallet_balance": 1000}}`, opts.WalletID)
+	})
+
+	_, _, err := client.WalletService.GetWalletBalance(&GetWalletBalanceOptions{WalletID: 1})
+	require.NoError(t, err)
+	_, _, err = client.WalletService.GetWalletBalance(&GetWalletBalanceOptions{WalletID: 2})
+	require.Error(t, err)
+	require.FileExists(t, filepath.Join(fixtureDir, "wallet", "get_wallet_balance.json"))
+
+	// Replays without a server or any certs
+	replay, err := NewClient(ConnectionModeHTTP, WithManualConfig(config.ChiaConfig{}), WithReplay(fixtureDir),
+		WithBaseURL(&url.URL{Scheme: "https", Host: "node.invalid"}))
+	require.NoError(t, err)
+
+	balance, _, err := replay.WalletService.GetWalletBalance(&GetWalletBalanceOptions{WalletID: 1})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), balance.Balance.MustGet().ConfirmedWalletBalance.Uint64())
+
+	_, _, err = replay.WalletService.GetWalletBalance(&GetWalletBalanceOptions{WalletID: 2})
+	require.EqualError(t, err, "wallet id 2 does not exist")
+
+	_, _, err = replay.WalletService.GetWalletBalance(&GetWalletBalanceOptions{WalletID: 3})
+	require.ErrorIs(t, err, httpclient.ErrNoFixture)
+	require.ErrorContains(t, err, `wallet/get_wallet_balance with request {"wallet_id":3}`)
+}
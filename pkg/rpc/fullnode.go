@@ -1,6 +1,9 @@
 package rpc
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/samber/mo"
@@ -11,38 +14,7 @@ import (
 
 // FullNodeService encapsulates full node RPC methods
 type FullNodeService struct {
-	client *Client
-}
-
-// NewRequest returns a new request specific to the wallet service
-func (s *FullNodeService) NewRequest(rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
-	return s.client.NewRequest(rpcinterface.ServiceFullNode, rpcEndpoint, opt)
-}
-
-// GetClient returns the active client for the service
-func (s *FullNodeService) GetClient() rpcinterface.Client {
-	return s.client
-}
-
-// GetConnectionsOptions options to filter get_connections
-type GetConnectionsOptions struct {
-	NodeType types.NodeType `json:"node_type,omitempty"`
-}
-
-// GetConnectionsResponse get_connections response format
-type GetConnectionsResponse struct {
-	rpcinterface.Response
-	Connections mo.Option[[]types.Connection] `json:"connections"`
-}
-
-// GetConnections returns connections
-func (s *FullNodeService) GetConnections(opts *GetConnectionsOptions) (*GetConnectionsResponse, *http.Response, error) {
-	return Do(s, "get_connections", opts, &GetConnectionsResponse{})
-}
-
-// GetNetworkInfo gets the network name and prefix from the full node
-func (s *FullNodeService) GetNetworkInfo(opts *GetNetworkInfoOptions) (*GetNetworkInfoResponse, *http.Response, error) {
-	return Do(s, "get_network_info", opts, &GetNetworkInfoResponse{})
+	SharedService
 }
 
 // GetBlockchainStateResponse is the blockchain state RPC response
@@ -51,14 +23,9 @@ type GetBlockchainStateResponse struct {
 	BlockchainState mo.Option[types.BlockchainState] `json:"blockchain_state,omitempty"`
 }
 
-// GetVersion returns the application version for the service
-func (s *FullNodeService) GetVersion(opts *GetVersionOptions) (*GetVersionResponse, *http.Response, error) {
-	return Do(s, "get_version", opts, &GetVersionResponse{})
-}
-
 // GetBlockchainState returns blockchain state
-func (s *FullNodeService) GetBlockchainState() (*GetBlockchainStateResponse, *http.Response, error) {
-	return Do(s, "get_blockchain_state", nil, &GetBlockchainStateResponse{})
+func (s *FullNodeService) GetBlockchainState(options ...rpcinterface.RequestOptionFunc) (*GetBlockchainStateResponse, *http.Response, error) {
+	return Do(s, "get_blockchain_state", nil, &GetBlockchainStateResponse{}, options...)
 }
 
 // GetBlockOptions options for get_block rpc call
@@ -73,8 +40,8 @@ type GetBlockResponse struct {
 }
 
 // GetBlock full_node->get_block RPC method
-func (s *FullNodeService) GetBlock(opts *GetBlockOptions) (*GetBlockResponse, *http.Response, error) {
-	return Do(s, "get_block", opts, &GetBlockResponse{})
+func (s *FullNodeService) GetBlock(opts *GetBlockOptions, options ...rpcinterface.RequestOptionFunc) (*GetBlockResponse, *http.Response, error) {
+	return Do(s, "get_block", opts, &GetBlockResponse{}, options...)
 }
 
 // GetBlocksOptions options for get_blocks rpc call
@@ -94,8 +61,13 @@ type GetBlocksResponse struct {
 }
 
 // GetBlocks full_node->get_blocks RPC method
-func (s *FullNodeService) GetBlocks(opts *GetBlocksOptions) (*GetBlocksResponse, *http.Response, error) {
-	return Do(s, "get_blocks", opts, &GetBlocksResponse{})
+func (s *FullNodeService) GetBlocks(opts *GetBlocksOptions, options ...rpcinterface.RequestOptionFunc) (*GetBlocksResponse, *http.Response, error) {
+	if opts != nil && opts.ExcludeReorged {
+		if err := s.client.RequireVersion(rpcinterface.ServiceFullNode, MinVersionExcludeReorged, "get_blocks with exclude_reorged", options...); err != nil {
+			return nil, nil, err
+		}
+	}
+	return Do(s, "get_blocks", opts, &GetBlocksResponse{}, options...)
 }
 
 // GetBlockCountMetricsResponse response for get_block_count_metrics rpc call
@@ -105,8 +77,8 @@ type GetBlockCountMetricsResponse struct {
 }
 
 // GetBlockCountMetrics gets metrics about blocks
-func (s *FullNodeService) GetBlockCountMetrics() (*GetBlockCountMetricsResponse, *http.Response, error) {
-	return Do(s, "get_block_count_metrics", nil, &GetBlockCountMetricsResponse{})
+func (s *FullNodeService) GetBlockCountMetrics(options ...rpcinterface.RequestOptionFunc) (*GetBlockCountMetricsResponse, *http.Response, error) {
+	return Do(s, "get_block_count_metrics", nil, &GetBlockCountMetricsResponse{}, options...)
 }
 
 // GetBlockByHeightOptions options for get_block_record_by_height and get_block rpc call
@@ -121,19 +93,110 @@ type GetBlockRecordResponse struct {
 }
 
 // GetBlockRecordByHeight full_node->get_block_record_by_height RPC method
-func (s *FullNodeService) GetBlockRecordByHeight(opts *GetBlockByHeightOptions) (*GetBlockRecordResponse, *http.Response, error) {
-	return Do(s, "get_block_record_by_height", opts, &GetBlockRecordResponse{})
+func (s *FullNodeService) GetBlockRecordByHeight(opts *GetBlockByHeightOptions, options ...rpcinterface.RequestOptionFunc) (*GetBlockRecordResponse, *http.Response, error) {
+	return Do(s, "get_block_record_by_height", opts, &GetBlockRecordResponse{}, options...)
+}
+
+// GetBlockRecordOptions options for get_block_record rpc call
+type GetBlockRecordOptions struct {
+	HeaderHash types.Bytes32 `json:"header_hash"`
+}
+
+// GetBlockRecord full_node->get_block_record RPC method
+func (s *FullNodeService) GetBlockRecord(opts *GetBlockRecordOptions, options ...rpcinterface.RequestOptionFunc) (*GetBlockRecordResponse, *http.Response, error) {
+	return Do(s, "get_block_record", opts, &GetBlockRecordResponse{}, options...)
+}
+
+// GetBlockRecordsOptions options for get_block_records rpc call
+// End is exclusive
+type GetBlockRecordsOptions struct {
+	Start uint32 `json:"start"`
+	End   uint32 `json:"end"`
+}
+
+// GetBlockRecordsResponse response from get_block_records
+type GetBlockRecordsResponse struct {
+	rpcinterface.Response
+	BlockRecords mo.Option[[]types.BlockRecord] `json:"block_records"`
+}
+
+// GetBlockRecords full_node->get_block_records RPC method
+func (s *FullNodeService) GetBlockRecords(opts *GetBlockRecordsOptions, options ...rpcinterface.RequestOptionFunc) (*GetBlockRecordsResponse, *http.Response, error) {
+	return Do(s, "get_block_records", opts, &GetBlockRecordsResponse{}, options...)
+}
+
+// GetUnfinishedBlockHeadersResponse response from get_unfinished_block_headers
+type GetUnfinishedBlockHeadersResponse struct {
+	rpcinterface.Response
+	Headers mo.Option[[]types.UnfinishedHeaderBlock] `json:"headers"`
+}
+
+// GetUnfinishedBlockHeaders full_node->get_unfinished_block_headers RPC method
+// Returns the headers of the unfinished blocks at the peak height
+func (s *FullNodeService) GetUnfinishedBlockHeaders(options ...rpcinterface.RequestOptionFunc) (*GetUnfinishedBlockHeadersResponse, *http.Response, error) {
+	return Do(s, "get_unfinished_block_headers", nil, &GetUnfinishedBlockHeadersResponse{}, options...)
+}
+
+// GetRecentSignagePointOrEOSOptions options for get_recent_signage_point_or_eos rpc call
+// Set exactly one of SPHash, to get a signage point, or ChallengeHash, to get an end of sub slot
+type GetRecentSignagePointOrEOSOptions struct {
+	SPHash        *types.Bytes32 `json:"sp_hash,omitempty"`
+	ChallengeHash *types.Bytes32 `json:"challenge_hash,omitempty"`
+}
+
+// GetRecentSignagePointOrEOSResponse response from get_recent_signage_point_or_eos
+// SignagePoint is set when requested by SPHash, and EOS when requested by ChallengeHash
+type GetRecentSignagePointOrEOSResponse struct {
+	rpcinterface.Response
+	SignagePoint mo.Option[types.SignagePoint]       `json:"signage_point"`
+	EOS          mo.Option[types.EndOfSubSlotBundle] `json:"eos"`
+	TimeReceived mo.Option[float64]                  `json:"time_received"`
+	Reverted     mo.Option[bool]                     `json:"reverted"`
+}
+
+// GetRecentSignagePointOrEOS full_node->get_recent_signage_point_or_eos RPC method
+func (s *FullNodeService) GetRecentSignagePointOrEOS(opts *GetRecentSignagePointOrEOSOptions, options ...rpcinterface.RequestOptionFunc) (*GetRecentSignagePointOrEOSResponse, *http.Response, error) {
+	return Do(s, "get_recent_signage_point_or_eos", opts, &GetRecentSignagePointOrEOSResponse{}, options...)
+}
+
+// GetBlockSpendsOptions options for get_block_spends and get_block_spends_with_conditions rpc calls
+type GetBlockSpendsOptions struct {
+	HeaderHash types.Bytes32 `json:"header_hash"`
+}
+
+// GetBlockSpendsResponse response from get_block_spends
+type GetBlockSpendsResponse struct {
+	rpcinterface.Response
+	BlockSpends mo.Option[[]types.CoinSpend] `json:"block_spends"`
+}
+
+// GetBlockSpends full_node->get_block_spends RPC method
+// Returns every coin spend in the block
+func (s *FullNodeService) GetBlockSpends(opts *GetBlockSpendsOptions, options ...rpcinterface.RequestOptionFunc) (*GetBlockSpendsResponse, *http.Response, error) {
+	return Do(s, "get_block_spends", opts, &GetBlockSpendsResponse{}, options...)
+}
+
+// GetBlockSpendsWithConditionsResponse response from get_block_spends_with_conditions
+type GetBlockSpendsWithConditionsResponse struct {
+	rpcinterface.Response
+	BlockSpendsWithConditions mo.Option[[]types.CoinSpendWithConditions] `json:"block_spends_with_conditions"`
+}
+
+// GetBlockSpendsWithConditions full_node->get_block_spends_with_conditions RPC method
+// Returns every coin spend in the block, with the conditions output by each spend
+func (s *FullNodeService) GetBlockSpendsWithConditions(opts *GetBlockSpendsOptions, options ...rpcinterface.RequestOptionFunc) (*GetBlockSpendsWithConditionsResponse, *http.Response, error) {
+	return Do(s, "get_block_spends_with_conditions", opts, &GetBlockSpendsWithConditionsResponse{}, options...)
 }
 
 // GetBlockByHeight helper function to get a full block by height, calls full_node->get_block_record_by_height RPC method then full_node->get_block RPC method
-func (s *FullNodeService) GetBlockByHeight(opts *GetBlockByHeightOptions) (*GetBlockResponse, *http.Response, error) {
+func (s *FullNodeService) GetBlockByHeight(opts *GetBlockByHeightOptions, options ...rpcinterface.RequestOptionFunc) (*GetBlockResponse, *http.Response, error) {
 	// Get Block Record
-	record, resp, err := s.GetBlockRecordByHeight(opts)
+	record, resp, err := s.GetBlockRecordByHeight(opts, options...)
 	if err != nil || record == nil {
 		return nil, resp, err
 	}
 
-	return Do(s, "get_block", GetBlockOptions{HeaderHash: record.BlockRecord.OrEmpty().HeaderHash}, &GetBlockResponse{})
+	return Do(s, "get_block", GetBlockOptions{HeaderHash: record.BlockRecord.OrEmpty().HeaderHash}, &GetBlockResponse{}, options...)
 }
 
 // GetAdditionsAndRemovalsOptions options for get_additions_and_removals
@@ -149,8 +212,8 @@ type GetAdditionsAndRemovalsResponse struct {
 }
 
 // GetAdditionsAndRemovals Gets additions and removals for a particular block hash
-func (s *FullNodeService) GetAdditionsAndRemovals(opts *GetAdditionsAndRemovalsOptions) (*GetAdditionsAndRemovalsResponse, *http.Response, error) {
-	return Do(s, "get_additions_and_removals", opts, &GetAdditionsAndRemovalsResponse{})
+func (s *FullNodeService) GetAdditionsAndRemovals(opts *GetAdditionsAndRemovalsOptions, options ...rpcinterface.RequestOptionFunc) (*GetAdditionsAndRemovalsResponse, *http.Response, error) {
+	return Do(s, "get_additions_and_removals", opts, &GetAdditionsAndRemovalsResponse{}, options...)
 }
 
 // GetCoinRecordsByPuzzleHashOptions request options for /get_coin_records_by_puzzle_hash
@@ -168,8 +231,8 @@ type GetCoinRecordsByPuzzleHashResponse struct {
 }
 
 // GetCoinRecordsByPuzzleHash returns coin records for a specified puzzle hash
-func (s *FullNodeService) GetCoinRecordsByPuzzleHash(opts *GetCoinRecordsByPuzzleHashOptions) (*GetCoinRecordsByPuzzleHashResponse, *http.Response, error) {
-	return Do(s, "get_coin_records_by_puzzle_hash", opts, &GetCoinRecordsByPuzzleHashResponse{})
+func (s *FullNodeService) GetCoinRecordsByPuzzleHash(opts *GetCoinRecordsByPuzzleHashOptions, options ...rpcinterface.RequestOptionFunc) (*GetCoinRecordsByPuzzleHashResponse, *http.Response, error) {
+	return Do(s, "get_coin_records_by_puzzle_hash", opts, &GetCoinRecordsByPuzzleHashResponse{}, options...)
 }
 
 // GetCoinRecordsByPuzzleHashesOptions request options for /get_coin_records_by_puzzle_hash
@@ -187,13 +250,13 @@ type GetCoinRecordsByPuzzleHashesResponse struct {
 }
 
 // GetCoinRecordsByPuzzleHashes returns coin records for a specified list of puzzle hashes
-func (s *FullNodeService) GetCoinRecordsByPuzzleHashes(opts *GetCoinRecordsByPuzzleHashesOptions) (*GetCoinRecordsByPuzzleHashesResponse, *http.Response, error) {
-	return Do(s, "get_coin_records_by_puzzle_hashes", opts, &GetCoinRecordsByPuzzleHashesResponse{})
+func (s *FullNodeService) GetCoinRecordsByPuzzleHashes(opts *GetCoinRecordsByPuzzleHashesOptions, options ...rpcinterface.RequestOptionFunc) (*GetCoinRecordsByPuzzleHashesResponse, *http.Response, error) {
+	return Do(s, "get_coin_records_by_puzzle_hashes", opts, &GetCoinRecordsByPuzzleHashesResponse{}, options...)
 }
 
 // GetCoinRecordByNameOptions request options for /get_coin_record_by_name
 type GetCoinRecordByNameOptions struct {
-	Name string `json:"name"`
+	Name types.Bytes32 `json:"name"`
 }
 
 // GetCoinRecordByNameResponse response from get_coin_record_by_name endpoint
@@ -203,8 +266,8 @@ type GetCoinRecordByNameResponse struct {
 }
 
 // GetCoinRecordByName request to get_coin_record_by_name endpoint
-func (s *FullNodeService) GetCoinRecordByName(opts *GetCoinRecordByNameOptions) (*GetCoinRecordByNameResponse, *http.Response, error) {
-	return Do(s, "get_coin_record_by_name", opts, &GetCoinRecordByNameResponse{})
+func (s *FullNodeService) GetCoinRecordByName(opts *GetCoinRecordByNameOptions, options ...rpcinterface.RequestOptionFunc) (*GetCoinRecordByNameResponse, *http.Response, error) {
+	return Do(s, "get_coin_record_by_name", opts, &GetCoinRecordByNameResponse{}, options...)
 }
 
 // GetCoinRecordsByHintOptions options for get_coin_records_by_hint
@@ -222,8 +285,48 @@ type GetCoinRecordsByHintResponse struct {
 }
 
 // GetCoinRecordsByHint returns coin records for a specified puzzle hash
-func (s *FullNodeService) GetCoinRecordsByHint(opts *GetCoinRecordsByHintOptions) (*GetCoinRecordsByHintResponse, *http.Response, error) {
-	return Do(s, "get_coin_records_by_hint", opts, &GetCoinRecordsByHintResponse{})
+func (s *FullNodeService) GetCoinRecordsByHint(opts *GetCoinRecordsByHintOptions, options ...rpcinterface.RequestOptionFunc) (*GetCoinRecordsByHintResponse, *http.Response, error) {
+	return Do(s, "get_coin_records_by_hint", opts, &GetCoinRecordsByHintResponse{}, options...)
+}
+
+// GetCoinRecordsByNamesOptions options for get_coin_records_by_names
+type GetCoinRecordsByNamesOptions struct {
+	Names             []types.Bytes32 `json:"names"`
+	IncludeSpentCoins bool            `json:"include_spent_coins"`
+	StartHeight       uint32          `json:"start_height,omitempty"`
+	EndHeight         uint32          `json:"end_height,omitempty"`
+}
+
+// GetCoinRecordsByNamesResponse Response for /get_coin_records_by_names
+type GetCoinRecordsByNamesResponse struct {
+	rpcinterface.Response
+	CoinRecords []types.CoinRecord `json:"coin_records"`
+}
+
+// GetCoinRecordsByNames returns coin records for a list of coin IDs
+// Use FetchCoinRecordsByNames for very large lists of coin IDs
+func (s *FullNodeService) GetCoinRecordsByNames(opts *GetCoinRecordsByNamesOptions, options ...rpcinterface.RequestOptionFunc) (*GetCoinRecordsByNamesResponse, *http.Response, error) {
+	return Do(s, "get_coin_records_by_names", opts, &GetCoinRecordsByNamesResponse{}, options...)
+}
+
+// GetCoinRecordsByParentIDsOptions options for get_coin_records_by_parent_ids
+type GetCoinRecordsByParentIDsOptions struct {
+	ParentIDs         []types.Bytes32 `json:"parent_ids"`
+	IncludeSpentCoins bool            `json:"include_spent_coins"`
+	StartHeight       uint32          `json:"start_height,omitempty"`
+	EndHeight         uint32          `json:"end_height,omitempty"`
+}
+
+// GetCoinRecordsByParentIDsResponse Response for /get_coin_records_by_parent_ids
+type GetCoinRecordsByParentIDsResponse struct {
+	rpcinterface.Response
+	CoinRecords []types.CoinRecord `json:"coin_records"`
+}
+
+// GetCoinRecordsByParentIDs returns coin records for the children of a list of coin IDs
+// Use FetchCoinRecordsByParentIDs for very large lists of parent IDs
+func (s *FullNodeService) GetCoinRecordsByParentIDs(opts *GetCoinRecordsByParentIDsOptions, options ...rpcinterface.RequestOptionFunc) (*GetCoinRecordsByParentIDsResponse, *http.Response, error) {
+	return Do(s, "get_coin_records_by_parent_ids", opts, &GetCoinRecordsByParentIDsResponse{}, options...)
 }
 
 // FullNodePushTXOptions options for pushing tx to full node mempool
@@ -238,8 +341,73 @@ type FullNodePushTXResponse struct {
 }
 
 // PushTX pushes a transaction to the full node
-func (s *FullNodeService) PushTX(opts *FullNodePushTXOptions) (*FullNodePushTXResponse, *http.Response, error) {
-	return Do(s, "push_tx", opts, &FullNodePushTXResponse{})
+func (s *FullNodeService) PushTX(opts *FullNodePushTXOptions, options ...rpcinterface.RequestOptionFunc) (*FullNodePushTXResponse, *http.Response, error) {
+	return Do(s, "push_tx", opts, &FullNodePushTXResponse{}, options...)
+}
+
+// GetAllMempoolTxIDsResponse response from get_all_mempool_tx_ids
+type GetAllMempoolTxIDsResponse struct {
+	rpcinterface.Response
+	TxIDs mo.Option[[]types.Bytes32] `json:"tx_ids"`
+}
+
+// GetAllMempoolTxIDs full_node->get_all_mempool_tx_ids RPC method
+func (s *FullNodeService) GetAllMempoolTxIDs(options ...rpcinterface.RequestOptionFunc) (*GetAllMempoolTxIDsResponse, *http.Response, error) {
+	return Do(s, "get_all_mempool_tx_ids", nil, &GetAllMempoolTxIDsResponse{}, options...)
+}
+
+// GetAllMempoolItemsResponse response from get_all_mempool_items
+// MempoolItems is keyed by the spend bundle name, in hex without the 0x prefix
+type GetAllMempoolItemsResponse struct {
+	rpcinterface.Response
+	MempoolItems mo.Option[map[string]types.MempoolItem] `json:"mempool_items"`
+}
+
+// GetAllMempoolItems full_node->get_all_mempool_items RPC method
+func (s *FullNodeService) GetAllMempoolItems(options ...rpcinterface.RequestOptionFunc) (*GetAllMempoolItemsResponse, *http.Response, error) {
+	return Do(s, "get_all_mempool_items", nil, &GetAllMempoolItemsResponse{}, options...)
+}
+
+// GetMempoolItemByTxIDOptions options for get_mempool_item_by_tx_id
+type GetMempoolItemByTxIDOptions struct {
+	TxID types.Bytes32 `json:"tx_id"`
+
+	// IncludePending also looks in the pending cache, for items that aren't valid yet
+	IncludePending bool `json:"include_pending,omitempty"`
+}
+
+// GetMempoolItemByTxIDResponse response from get_mempool_item_by_tx_id
+type GetMempoolItemByTxIDResponse struct {
+	rpcinterface.Response
+	MempoolItem mo.Option[types.MempoolItem] `json:"mempool_item"`
+}
+
+// GetMempoolItemByTxID full_node->get_mempool_item_by_tx_id RPC method
+func (s *FullNodeService) GetMempoolItemByTxID(opts *GetMempoolItemByTxIDOptions, options ...rpcinterface.RequestOptionFunc) (*GetMempoolItemByTxIDResponse, *http.Response, error) {
+	return Do(s, "get_mempool_item_by_tx_id", opts, &GetMempoolItemByTxIDResponse{}, options...)
+}
+
+// GetMempoolItemsByCoinNameOptions options for get_mempool_items_by_coin_name
+type GetMempoolItemsByCoinNameOptions struct {
+	CoinName types.Bytes32 `json:"coin_name"`
+
+	// IncludePending also looks in the pending cache, for items that aren't valid yet
+	IncludePending bool `json:"include_pending,omitempty"`
+}
+
+// GetMempoolItemsByCoinNameResponse response from get_mempool_items_by_coin_name
+type GetMempoolItemsByCoinNameResponse struct {
+	rpcinterface.Response
+	MempoolItems mo.Option[[]types.MempoolItem] `json:"mempool_items"`
+}
+
+// GetMempoolItemsByCoinName full_node->get_mempool_items_by_coin_name RPC method
+// Returns the mempool items that spend the coin
+func (s *FullNodeService) GetMempoolItemsByCoinName(opts *GetMempoolItemsByCoinNameOptions, options ...rpcinterface.RequestOptionFunc) (*GetMempoolItemsByCoinNameResponse, *http.Response, error) {
+	if err := s.client.RequireVersion(rpcinterface.ServiceFullNode, MinVersionMempoolItemsByCoinName, "get_mempool_items_by_coin_name", options...); err != nil {
+		return nil, nil, err
+	}
+	return Do(s, "get_mempool_items_by_coin_name", opts, &GetMempoolItemsByCoinNameResponse{}, options...)
 }
 
 // GetFeeEstimateOptions inputs to get a fee estimate
@@ -266,8 +434,11 @@ type GetFeeEstimateResponse struct {
 }
 
 // GetFeeEstimate endpoint
-func (s *FullNodeService) GetFeeEstimate(opts *GetFeeEstimateOptions) (*GetFeeEstimateResponse, *http.Response, error) {
-	return Do(s, "get_fee_estimate", opts, &GetFeeEstimateResponse{})
+func (s *FullNodeService) GetFeeEstimate(opts *GetFeeEstimateOptions, options ...rpcinterface.RequestOptionFunc) (*GetFeeEstimateResponse, *http.Response, error) {
+	if err := s.client.RequireVersion(rpcinterface.ServiceFullNode, MinVersionFeeEstimate, "get_fee_estimate", options...); err != nil {
+		return nil, nil, err
+	}
+	return Do(s, "get_fee_estimate", opts, &GetFeeEstimateResponse{}, options...)
 }
 
 // GetPuzzleAndSolutionOptions options for get_puzzle_and_solution rpc call
@@ -283,6 +454,368 @@ type GetPuzzleAndSolutionResponse struct {
 }
 
 // GetPuzzleAndSolution full_node-> get_puzzle_and_solution RPC method
-func (s *FullNodeService) GetPuzzleAndSolution(opts *GetPuzzleAndSolutionOptions) (*GetPuzzleAndSolutionResponse, *http.Response, error) {
-	return Do(s, "get_puzzle_and_solution", opts, &GetPuzzleAndSolutionResponse{})
+func (s *FullNodeService) GetPuzzleAndSolution(opts *GetPuzzleAndSolutionOptions, options ...rpcinterface.RequestOptionFunc) (*GetPuzzleAndSolutionResponse, *http.Response, error) {
+	return Do(s, "get_puzzle_and_solution", opts, &GetPuzzleAndSolutionResponse{}, options...)
+}
+
+// GetNetworkSpaceOptions options for get_network_space rpc call
+type GetNetworkSpaceOptions struct {
+	NewerBlockHeaderHash types.Bytes32 `json:"newer_block_header_hash"`
+	OlderBlockHeaderHash types.Bytes32 `json:"older_block_header_hash"`
+}
+
+// GetNetworkSpaceResponse response from get_network_space
+type GetNetworkSpaceResponse struct {
+	rpcinterface.Response
+	Space mo.Option[types.Uint128] `json:"space"`
+}
+
+// GetNetworkSpace full_node->get_network_space RPC method
+// Estimates the network space in bytes from the blocks between the two header hashes
+func (s *FullNodeService) GetNetworkSpace(opts *GetNetworkSpaceOptions, options ...rpcinterface.RequestOptionFunc) (*GetNetworkSpaceResponse, *http.Response, error) {
+	return Do(s, "get_network_space", opts, &GetNetworkSpaceResponse{}, options...)
+}
+
+// DefaultNetworkSpaceBlockWindow is the number of blocks EstimateNetworkSpace estimates over when not set, about one day
+const DefaultNetworkSpaceBlockWindow = 4608
+
+// EstimateNetworkSpaceOptions options for EstimateNetworkSpace
+type EstimateNetworkSpaceOptions struct {
+	// NewerBlockHeaderHash and OlderBlockHeaderHash are the blocks to estimate between
+	// When neither is set, the estimate is over the BlockWindow blocks before the peak instead. Setting only one is an error
+	NewerBlockHeaderHash types.Bytes32
+	OlderBlockHeaderHash types.Bytes32
+
+	// BlockWindow is the number of blocks before the peak to estimate over. Defaults to DefaultNetworkSpaceBlockWindow
+	BlockWindow uint32
+}
+
+// EstimateNetworkSpace returns the estimated network space in bytes, either between two header hashes or over a window
+// of blocks ending at the current peak
+func (s *FullNodeService) EstimateNetworkSpace(opts *EstimateNetworkSpaceOptions, options ...rpcinterface.RequestOptionFunc) (types.Uint128, error) {
+	if opts == nil {
+		opts = &EstimateNetworkSpaceOptions{}
+	}
+	newer, older := opts.NewerBlockHeaderHash, opts.OlderBlockHeaderHash
+	if (newer == types.Bytes32{}) != (older == types.Bytes32{}) {
+		return types.Uint128{}, errors.New("EstimateNetworkSpace requires both NewerBlockHeaderHash and OlderBlockHeaderHash, or neither")
+	}
+	if newer == (types.Bytes32{}) {
+		window := opts.BlockWindow
+		if window == 0 {
+			window = DefaultNetworkSpaceBlockWindow
+		}
+
+		state, _, err := s.GetBlockchainState(options...)
+		if err != nil {
+			return types.Uint128{}, err
+		}
+		peak, ok := state.BlockchainState.OrEmpty().Peak.Get()
+		if !ok {
+			return types.Uint128{}, errors.New("full node has no peak")
+		}
+		if peak.Height == 0 {
+			return types.Uint128{}, errors.New("not enough blocks to estimate the network space")
+		}
+		olderHeight := uint32(0)
+		if peak.Height > window {
+			olderHeight = peak.Height - window
+		}
+
+		record, _, err := s.GetBlockRecordByHeight(&GetBlockByHeightOptions{BlockHeight: int(olderHeight)}, options...)
+		if err != nil {
+			return types.Uint128{}, err
+		}
+		olderRecord, ok := record.BlockRecord.Get()
+		if !ok {
+			return types.Uint128{}, fmt.Errorf("no block record at height %d", olderHeight)
+		}
+		newer, older = peak.HeaderHash, olderRecord.HeaderHash
+	}
+
+	response, _, err := s.GetNetworkSpace(&GetNetworkSpaceOptions{
+		NewerBlockHeaderHash: newer,
+		OlderBlockHeaderHash: older,
+	}, options...)
+	if err != nil {
+		return types.Uint128{}, err
+	}
+	space, ok := response.Space.Get()
+	if !ok {
+		return types.Uint128{}, errors.New("get_network_space did not return the space")
+	}
+	return space, nil
+}
+
+// GetAggsigAdditionalDataResponse response from get_aggsig_additional_data
+type GetAggsigAdditionalDataResponse struct {
+	rpcinterface.Response
+	AdditionalData mo.Option[types.Bytes32] `json:"additional_data"`
+}
+
+// GetAggsigAdditionalData full_node->get_aggsig_additional_data RPC method
+// Returns the data appended to AGG_SIG_ME messages on this network, which is the genesis challenge
+func (s *FullNodeService) GetAggsigAdditionalData(options ...rpcinterface.RequestOptionFunc) (*GetAggsigAdditionalDataResponse, *http.Response, error) {
+	return Do(s, "get_aggsig_additional_data", nil, &GetAggsigAdditionalDataResponse{}, options...)
+}
+
+const (
+	// DefaultBlocksPageSize is the number of blocks requested per get_blocks call when iterating blocks
+	DefaultBlocksPageSize = 20
+
+	// MaxBlocksPageSize is the most blocks requested per get_blocks call when iterating blocks
+	// Blocks with transaction generators can be large, so pages are capped to keep responses manageable
+	MaxBlocksPageSize = 100
+
+	// DefaultCoinRecordsWindowSize is the number of heights requested per get_coin_records_by_puzzle_hashes call when iterating
+	DefaultCoinRecordsWindowSize = 10000
+)
+
+// IterateBlocksOptions options for IterateBlocks
+type IterateBlocksOptions struct {
+	// Start is the first height to return
+	Start int
+	// End is the height to stop at (exclusive, the same as get_blocks)
+	End int
+	// PageSize is the number of blocks to request per get_blocks call. Capped at MaxBlocksPageSize
+	PageSize int
+
+	ExcludeHeaderHash bool
+	ExcludeReorged    bool
+}
+
+// IterateBlocks returns an iterator over all blocks in the height range, calling get_blocks one page at a time
+func (s *FullNodeService) IterateBlocks(opts *IterateBlocksOptions, options ...rpcinterface.RequestOptionFunc) *Iterator[types.FullBlock] {
+	if opts == nil {
+		return errorIterator[types.FullBlock](errors.New("IterateBlocks requires options with the block range"))
+	}
+	pageSize := clampPageSize(opts.PageSize, DefaultBlocksPageSize, MaxBlocksPageSize)
+	cursor := opts.Start
+
+	return NewIterator(func(_ context.Context, options ...rpcinterface.RequestOptionFunc) ([]types.FullBlock, bool, error) {
+		end := cursor + pageSize
+		if end > opts.End {
+			end = opts.End
+		}
+		if cursor >= end {
+			return nil, true, nil
+		}
+
+		blocks, _, err := s.GetBlocks(&GetBlocksOptions{
+			Start:             cursor,
+			End:               end,
+			ExcludeHeaderHash: opts.ExcludeHeaderHash,
+			ExcludeReorged:    opts.ExcludeReorged,
+		}, options...)
+		if err != nil {
+			return nil, false, err
+		}
+
+		cursor = end
+		return blocks.Blocks.OrEmpty(), cursor >= opts.End, nil
+	}, options...)
+}
+
+// IterateCoinRecordsByPuzzleHashesOptions options for IterateCoinRecordsByPuzzleHashes
+type IterateCoinRecordsByPuzzleHashesOptions struct {
+	PuzzleHashes      []types.Bytes32
+	IncludeSpentCoins bool
+	StartHeight       uint32
+	// EndHeight is the height to stop at (exclusive). If unset, iterates up to the current peak
+	EndHeight uint32
+	// WindowSize is the number of heights to request coin records for per call
+	WindowSize uint32
+}
+
+// IterateCoinRecordsByPuzzleHashes returns an iterator over the coin records for the puzzle hashes
+// get_coin_records_by_puzzle_hashes is called for one window of heights at a time, so each response stays small
+func (s *FullNodeService) IterateCoinRecordsByPuzzleHashes(opts *IterateCoinRecordsByPuzzleHashesOptions, options ...rpcinterface.RequestOptionFunc) *Iterator[types.CoinRecord] {
+	if opts == nil {
+		return errorIterator[types.CoinRecord](errors.New("IterateCoinRecordsByPuzzleHashes requires options with the puzzle hashes"))
+	}
+	windowSize := opts.WindowSize
+	if windowSize == 0 {
+		windowSize = DefaultCoinRecordsWindowSize
+	}
+	cursor := opts.StartHeight
+	endHeight := opts.EndHeight
+
+	return NewIterator(func(_ context.Context, options ...rpcinterface.RequestOptionFunc) ([]types.CoinRecord, bool, error) {
+		if endHeight == 0 {
+			state, _, err := s.GetBlockchainState(options...)
+			if err != nil {
+				return nil, false, err
+			}
+			peak := state.BlockchainState.OrEmpty().Peak
+			if peak.IsAbsent() {
+				return nil, true, nil
+			}
+			endHeight = peak.MustGet().Height + 1
+		}
+
+		end := cursor + windowSize
+		if end > endHeight || end < cursor {
+			end = endHeight
+		}
+		if cursor >= end {
+			return nil, true, nil
+		}
+
+		records, _, err := s.GetCoinRecordsByPuzzleHashes(&GetCoinRecordsByPuzzleHashesOptions{
+			PuzzleHash:        opts.PuzzleHashes,
+			IncludeSpentCoins: opts.IncludeSpentCoins,
+			StartHeight:       cursor,
+			EndHeight:         end,
+		}, options...)
+		if err != nil {
+			return nil, false, err
+		}
+
+		cursor = end
+		return records.CoinRecords, cursor >= endHeight, nil
+	}, options...)
+}
+
+const (
+	// DefaultBulkBlocksChunkSize is the number of blocks requested per get_blocks call by FetchBlocks
+	DefaultBulkBlocksChunkSize = 20
+
+	// DefaultBulkAdditionsAndRemovalsChunkSize is the number of blocks each worker handles per chunk in FetchAdditionsAndRemovals
+	DefaultBulkAdditionsAndRemovalsChunkSize = 10
+
+	// DefaultBulkPuzzleHashChunkSize is the number of puzzle hashes sent per get_coin_records_by_puzzle_hashes call
+	DefaultBulkPuzzleHashChunkSize = 500
+
+	// DefaultBulkCoinIDChunkSize is the number of coin IDs sent per get_coin_records_by_names or
+	// get_coin_records_by_parent_ids call
+	DefaultBulkCoinIDChunkSize = 500
+)
+
+// FetchBlocksOptions options for FetchBlocks
+type FetchBlocksOptions struct {
+	// Start is the first height to return
+	Start int
+	// End is the height to stop at (exclusive, the same as get_blocks)
+	End int
+
+	ExcludeHeaderHash bool
+	ExcludeReorged    bool
+
+	// Bulk controls how the range is split and fetched. Defaults are used if nil
+	Bulk *BulkFetchOptions
+}
+
+// FetchBlocks fetches all blocks in the height range, splitting the range into chunks that are fetched concurrently
+// Blocks are returned in height order. If a chunk fails after all retries, the blocks from all chunks before the
+// failed chunk are returned along with a *ChunkError
+func (s *FullNodeService) FetchBlocks(ctx context.Context, opts *FetchBlocksOptions, options ...rpcinterface.RequestOptionFunc) ([]types.FullBlock, error) {
+	if opts == nil {
+		return nil, errors.New("FetchBlocks requires options with the height range")
+	}
+	bulk := opts.Bulk.withDefaults(DefaultBulkBlocksChunkSize)
+	if opts.End <= opts.Start {
+		return nil, nil
+	}
+	chunks := (opts.End - opts.Start + bulk.ChunkSize - 1) / bulk.ChunkSize
+
+	return bulkFetch(ctx, chunks, bulk, func(ctx context.Context, chunk int) ([]types.FullBlock, error) {
+		start := opts.Start + chunk*bulk.ChunkSize
+		end := start + bulk.ChunkSize
+		if end > opts.End {
+			end = opts.End
+		}
+		blocks, _, err := s.GetBlocks(&GetBlocksOptions{
+			Start:             start,
+			End:               end,
+			ExcludeHeaderHash: opts.ExcludeHeaderHash,
+			ExcludeReorged:    opts.ExcludeReorged,
+		}, withContextOption(ctx, options)...)
+		if err != nil {
+			return nil, err
+		}
+		return blocks.Blocks.OrEmpty(), nil
+	})
+}
+
+// FetchAdditionsAndRemovals calls get_additions_and_removals for every header hash, using a bounded pool of workers
+// Responses are returned in the same order as the header hashes. If a chunk fails after all retries, the responses
+// from all chunks before the failed chunk are returned along with a *ChunkError. Defaults are used if bulkOpts is nil
+func (s *FullNodeService) FetchAdditionsAndRemovals(ctx context.Context, headerHashes []types.Bytes32, bulkOpts *BulkFetchOptions, options ...rpcinterface.RequestOptionFunc) ([]*GetAdditionsAndRemovalsResponse, error) {
+	bulk := bulkOpts.withDefaults(DefaultBulkAdditionsAndRemovalsChunkSize)
+	chunks := (len(headerHashes) + bulk.ChunkSize - 1) / bulk.ChunkSize
+
+	return bulkFetch(ctx, chunks, bulk, func(ctx context.Context, chunk int) ([]*GetAdditionsAndRemovalsResponse, error) {
+		var responses []*GetAdditionsAndRemovalsResponse
+		for _, headerHash := range chunkOf(headerHashes, chunk, bulk.ChunkSize) {
+			response, _, err := s.GetAdditionsAndRemovals(&GetAdditionsAndRemovalsOptions{HeaderHash: headerHash}, withContextOption(ctx, options)...)
+			if err != nil {
+				return nil, err
+			}
+			responses = append(responses, response)
+		}
+		return responses, nil
+	})
+}
+
+// FetchCoinRecordsByPuzzleHashes is GetCoinRecordsByPuzzleHashes for very large lists of puzzle hashes
+// The puzzle hashes are split into chunks (DefaultBulkPuzzleHashChunkSize per request unless set) that are fetched
+// concurrently. Coin records are returned in the order of the chunks they were requested in
+func (s *FullNodeService) FetchCoinRecordsByPuzzleHashes(ctx context.Context, opts *GetCoinRecordsByPuzzleHashesOptions, bulkOpts *BulkFetchOptions, options ...rpcinterface.RequestOptionFunc) ([]types.CoinRecord, error) {
+	if opts == nil {
+		return nil, errors.New("FetchCoinRecordsByPuzzleHashes requires options with the puzzle hashes")
+	}
+	bulk := bulkOpts.withDefaults(DefaultBulkPuzzleHashChunkSize)
+	chunks := (len(opts.PuzzleHash) + bulk.ChunkSize - 1) / bulk.ChunkSize
+
+	return bulkFetch(ctx, chunks, bulk, func(ctx context.Context, chunk int) ([]types.CoinRecord, error) {
+		chunkOpts := *opts
+		chunkOpts.PuzzleHash = chunkOf(opts.PuzzleHash, chunk, bulk.ChunkSize)
+		records, _, err := s.GetCoinRecordsByPuzzleHashes(&chunkOpts, withContextOption(ctx, options)...)
+		if err != nil {
+			return nil, err
+		}
+		return records.CoinRecords, nil
+	})
+}
+
+// FetchCoinRecordsByNames is GetCoinRecordsByNames for very large lists of coin IDs
+// The coin IDs are split into chunks (DefaultBulkCoinIDChunkSize per request unless set) that are fetched
+// concurrently. Coin records are returned in the order of the chunks they were requested in
+func (s *FullNodeService) FetchCoinRecordsByNames(ctx context.Context, opts *GetCoinRecordsByNamesOptions, bulkOpts *BulkFetchOptions, options ...rpcinterface.RequestOptionFunc) ([]types.CoinRecord, error) {
+	if opts == nil {
+		return nil, errors.New("FetchCoinRecordsByNames requires options with the coin IDs")
+	}
+	bulk := bulkOpts.withDefaults(DefaultBulkCoinIDChunkSize)
+	chunks := (len(opts.Names) + bulk.ChunkSize - 1) / bulk.ChunkSize
+
+	return bulkFetch(ctx, chunks, bulk, func(ctx context.Context, chunk int) ([]types.CoinRecord, error) {
+		chunkOpts := *opts
+		chunkOpts.Names = chunkOf(opts.Names, chunk, bulk.ChunkSize)
+		records, _, err := s.GetCoinRecordsByNames(&chunkOpts, withContextOption(ctx, options)...)
+		if err != nil {
+			return nil, err
+		}
+		return records.CoinRecords, nil
+	})
+}
+
+// FetchCoinRecordsByParentIDs is GetCoinRecordsByParentIDs for very large lists of parent IDs
+// The parent IDs are split into chunks (DefaultBulkCoinIDChunkSize per request unless set) that are fetched
+// concurrently. Coin records are returned in the order of the chunks they were requested in
+func (s *FullNodeService) FetchCoinRecordsByParentIDs(ctx context.Context, opts *GetCoinRecordsByParentIDsOptions, bulkOpts *BulkFetchOptions, options ...rpcinterface.RequestOptionFunc) ([]types.CoinRecord, error) {
+	if opts == nil {
+		return nil, errors.New("FetchCoinRecordsByParentIDs requires options with the parent IDs")
+	}
+	bulk := bulkOpts.withDefaults(DefaultBulkCoinIDChunkSize)
+	chunks := (len(opts.ParentIDs) + bulk.ChunkSize - 1) / bulk.ChunkSize
+
+	return bulkFetch(ctx, chunks, bulk, func(ctx context.Context, chunk int) ([]types.CoinRecord, error) {
+		chunkOpts := *opts
+		chunkOpts.ParentIDs = chunkOf(opts.ParentIDs, chunk, bulk.ChunkSize)
+		records, _, err := s.GetCoinRecordsByParentIDs(&chunkOpts, withContextOption(ctx, options)...)
+		if err != nil {
+			return nil, err
+		}
+		return records.CoinRecords, nil
+	})
 }
@@ -0,0 +1,234 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+func TestGetBlockchainStateWithContext(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	// Hold the response until the test is done, so only the context can end the request
+	release := make(chan struct{})
+	defer close(release)
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.FullNodeService.GetBlockchainState(WithContext(ctx))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPushTXConsensusError(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/push_tx", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": false, "error": "Failed to include transaction 0x6f1c, error DOUBLE_SPEND"}`)
+	})
+
+	_, _, err := client.FullNodeService.PushTX(&FullNodePushTXOptions{})
+	require.ErrorIs(t, err, rpcinterface.ErrDoubleSpend)
+	require.NotErrorIs(t, err, rpcinterface.ErrAssertHeightAbsoluteFailed)
+	require.NotErrorIs(t, err, rpcinterface.ErrWalletNotSynced)
+
+	var rpcErr *rpcinterface.ChiaRPCError
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, rpcinterface.ServiceFullNode, rpcErr.Service)
+	require.Equal(t, rpcinterface.Endpoint("push_tx"), rpcErr.Endpoint)
+	require.Equal(t, http.StatusOK, rpcErr.StatusCode)
+	code, ok := rpcErr.ConsensusErr()
+	require.True(t, ok)
+	require.Equal(t, rpcinterface.ErrDoubleSpend, code)
+
+	// Only codes in chia's error format match, not any upper case word in the message
+	require.ErrorIs(t, &rpcinterface.ChiaRPCError{Message: "Err.MEMPOOL_CONFLICT"}, rpcinterface.ErrMempoolConflict)
+	require.NotErrorIs(t, &rpcinterface.ChiaRPCError{Message: "MEMPOOL_CONFLICT in WALLET_NAME"}, rpcinterface.ErrMempoolConflict)
+}
+
+func TestGetCoinRecordByNameNotFound(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_coin_record_by_name", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": false, "error": "Coin record 0x6f1c not found"}`)
+	})
+
+	name := getBytes32FromHexString(t, "0x6f1c000000000000000000000000000000000000000000000000000000000000")
+	_, _, err := client.FullNodeService.GetCoinRecordByName(&GetCoinRecordByNameOptions{Name: name})
+	require.ErrorIs(t, err, rpcinterface.ErrCoinNotFound)
+	require.NotErrorIs(t, err, rpcinterface.ErrBlockNotFound)
+}
+
+func TestGetMempoolItemByTxID(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_mempool_item_by_tx_id", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, fixture("fullnode/get_mempool_item_by_tx_id.json"))
+	})
+
+	txID := getBytes32FromHexString(t, "0x9999999999999999999999999999999999999999999999999999999999999999")
+	response, _, err := client.FullNodeService.GetMempoolItemByTxID(&GetMempoolItemByTxIDOptions{TxID: txID})
+	require.NoError(t, err)
+
+	item := response.MempoolItem.MustGet()
+	require.Equal(t, txID, item.SpendBundleName)
+	require.Equal(t, uint64(100), item.Fee)
+	require.Equal(t, uint64(2000000), item.Cost)
+	require.Equal(t, 0.00005, item.FeePerCost())
+	require.Len(t, item.SpendBundle.CoinSpends, 1)
+	require.Len(t, item.Additions, 1)
+	require.Equal(t, uint64(900), item.Additions[0].Amount)
+	require.Len(t, item.Removals, 1)
+	require.False(t, item.AssertHeight.IsPresent())
+
+	require.False(t, item.NPCResult.Error.IsPresent())
+	conds := item.NPCResult.Conds.MustGet()
+	require.Equal(t, uint64(100), conds.ReserveFee)
+	require.Equal(t, uint64(900), conds.AdditionAmount.Uint64())
+	require.Len(t, conds.Spends, 1)
+
+	spend := conds.Spends[0]
+	require.Equal(t, uint64(1000), spend.CoinAmount)
+	require.Len(t, spend.CreateCoin, 2)
+	require.Equal(t, uint64(900), spend.CreateCoin[0].Value().Amount)
+	require.False(t, spend.CreateCoin[0].Value().Hint.IsPresent())
+	require.True(t, spend.CreateCoin[1].Value().Hint.IsPresent())
+	require.Len(t, spend.AggSigMe, 1)
+	require.Equal(t, types.Bytes{0xca, 0xfe}, spend.AggSigMe[0].Value().Message)
+}
+
+func TestGetMempoolItemsByCoinNameUnsupported(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_version", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"success": true, "version": "2.1.1"}`)
+	})
+
+	_, _, err := client.FullNodeService.GetMempoolItemsByCoinName(&GetMempoolItemsByCoinNameOptions{})
+	require.ErrorIs(t, err, ErrUnsupportedByNode)
+}
+
+func TestGetBlockSpendsWithConditions(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_block_spends_with_conditions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "block_spends_with_conditions": [{
+			"coin_spend": {
+				"coin": {"parent_coin_info": "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "puzzle_hash": "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "amount": 1000},
+				"puzzle_reveal": "0xff01ff8080",
+				"solution": "0xff80"
+			},
+			"conditions": [
+				{"opcode": "0x33", "vars": ["dddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddd", "0384"]},
+				{"opcode": "0x34", "vars": ["64"]}
+			]
+		}]}`)
+	})
+
+	response, _, err := client.FullNodeService.GetBlockSpendsWithConditions(&GetBlockSpendsOptions{})
+	require.NoError(t, err)
+
+	spends := response.BlockSpendsWithConditions.MustGet()
+	require.Len(t, spends, 1)
+	require.Equal(t, uint64(1000), spends[0].CoinSpend.Coin.Amount)
+	require.Len(t, spends[0].Conditions, 2)
+	require.Equal(t, types.ConditionOpcodeCreateCoin, spends[0].Conditions[0].Opcode)
+	require.Equal(t, types.Bytes{0x03, 0x84}, spends[0].Conditions[0].Vars[1])
+	require.Equal(t, types.ConditionOpcodeReserveFee, spends[0].Conditions[1].Opcode)
+}
+
+func TestGetRecentSignagePointOrEOS(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_recent_signage_point_or_eos", func(w http.ResponseWriter, r *http.Request) {
+		// Only one of the hashes may be sent
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(body), "challenge_hash")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "signage_point": {"cc_vdf": null, "cc_proof": null, "rc_vdf": null, "rc_proof": null}, "time_received": 1700000000.5, "reverted": false}`)
+	})
+
+	spHash := getBytes32FromHexString(t, "0x9999999999999999999999999999999999999999999999999999999999999999")
+	response, _, err := client.FullNodeService.GetRecentSignagePointOrEOS(&GetRecentSignagePointOrEOSOptions{SPHash: &spHash})
+	require.NoError(t, err)
+	require.True(t, response.SignagePoint.IsPresent())
+	require.False(t, response.EOS.IsPresent())
+	require.Equal(t, 1700000000.5, response.TimeReceived.MustGet())
+}
+
+func TestEstimateNetworkSpace(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	peakHash := "0x1111111111111111111111111111111111111111111111111111111111111111"
+	olderHash := "0x2222222222222222222222222222222222222222222222222222222222222222"
+
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"success": true, "blockchain_state": {"peak": {"header_hash": "%s", "height": 5000}}}`, peakHash)
+	})
+	mux.HandleFunc("/get_block_record_by_height", func(w http.ResponseWriter, r *http.Request) {
+		opts := &GetBlockByHeightOptions{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(opts))
+		assert.Equal(t, 5000-DefaultNetworkSpaceBlockWindow, opts.BlockHeight)
+		_, _ = fmt.Fprintf(w, `{"success": true, "block_record": {"header_hash": "%s", "height": %d}}`, olderHash, opts.BlockHeight)
+	})
+	mux.HandleFunc("/get_network_space", func(w http.ResponseWriter, r *http.Request) {
+		opts := &GetNetworkSpaceOptions{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(opts))
+		assert.Equal(t, getBytes32FromHexString(t, peakHash), opts.NewerBlockHeaderHash)
+		assert.Equal(t, getBytes32FromHexString(t, olderHash), opts.OlderBlockHeaderHash)
+		// Larger than a uint64
+		_, _ = fmt.Fprint(w, `{"success": true, "space": 36893488147419103232}`)
+	})
+
+	space, err := client.FullNodeService.EstimateNetworkSpace(nil)
+	require.NoError(t, err)
+	require.Equal(t, types.NewUint128(0, 2), space)
+
+	// Header hashes are used directly when set
+	space, err = client.FullNodeService.EstimateNetworkSpace(&EstimateNetworkSpaceOptions{
+		NewerBlockHeaderHash: getBytes32FromHexString(t, peakHash),
+		OlderBlockHeaderHash: getBytes32FromHexString(t, olderHash),
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.NewUint128(0, 2), space)
+
+	// Only one header hash is an error, instead of silently estimating over the default window
+	_, err = client.FullNodeService.EstimateNetworkSpace(&EstimateNetworkSpaceOptions{
+		NewerBlockHeaderHash: getBytes32FromHexString(t, peakHash),
+	})
+	require.Error(t, err)
+	_, err = client.FullNodeService.EstimateNetworkSpace(&EstimateNetworkSpaceOptions{
+		OlderBlockHeaderHash: getBytes32FromHexString(t, olderHash),
+	})
+	require.Error(t, err)
+}
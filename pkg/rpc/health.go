@@ -0,0 +1,212 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+// HealthServices are the services checked by Health when the running services can't be determined from the daemon
+var HealthServices = []rpcinterface.ServiceType{
+	rpcinterface.ServiceFullNode,
+	rpcinterface.ServiceWallet,
+	rpcinterface.ServiceFarmer,
+	rpcinterface.ServiceHarvester,
+	rpcinterface.ServiceCrawler,
+	rpcinterface.ServiceDataLayer,
+	rpcinterface.ServiceTimelord,
+}
+
+// HealthOptions configures which services are checked by Health
+type HealthOptions struct {
+	// Services to check. Defaults to the services the daemon reports as running, or HealthServices if the daemon
+	// can't be reached, such as in HTTP mode
+	Services []rpcinterface.ServiceType
+}
+
+// HealthReport is the state of the chia services, suitable for serving from a health endpoint
+type HealthReport struct {
+	CheckedAt time.Time `json:"checked_at"`
+
+	// RunningServices are the services the daemon reports as running. Nil if the daemon couldn't be reached
+	RunningServices []ServiceFullName `json:"running_services"`
+
+	// DaemonError is why the running services couldn't be retrieved from the daemon, if they couldn't be
+	DaemonError string `json:"daemon_error,omitempty"`
+
+	Services []ServiceHealth `json:"services"`
+}
+
+// ServiceHealth is the state of a single service
+type ServiceHealth struct {
+	Service string `json:"service"`
+
+	// Running is whether the daemon reports the service as running. Nil if the daemon couldn't be reached
+	Running *bool `json:"running"`
+
+	// Reachable is true when the service responded to the RPC calls for the report
+	Reachable bool   `json:"reachable"`
+	Version   string `json:"version,omitempty"`
+	Error     string `json:"error,omitempty"`
+
+	// FullNode is the sync state of the full node, only set for the full node service
+	FullNode *FullNodeSyncState `json:"full_node,omitempty"`
+
+	// Wallet is the sync state of the wallet, only set for the wallet service
+	Wallet *WalletSyncState `json:"wallet,omitempty"`
+}
+
+// FullNodeSyncState is the sync state of the full node
+type FullNodeSyncState struct {
+	Synced        bool   `json:"synced"`
+	SyncMode      bool   `json:"sync_mode"`
+	PeakHeight    uint32 `json:"peak_height"`
+	SyncTipHeight uint32 `json:"sync_tip_height"`
+}
+
+// WalletSyncState is the sync state of the wallet
+type WalletSyncState struct {
+	Synced  bool   `json:"synced"`
+	Syncing bool   `json:"syncing"`
+	Height  uint32 `json:"height"`
+}
+
+// Ready returns true if every checked service is reachable, and the full node and wallet are synced
+func (r *HealthReport) Ready() bool {
+	for _, service := range r.Services {
+		if !service.Ready() {
+			return false
+		}
+	}
+	return true
+}
+
+// Ready returns true if the service is reachable without errors, and synced if it is a full node or wallet
+func (s *ServiceHealth) Ready() bool {
+	if !s.Reachable || s.Error != "" {
+		return false
+	}
+	if s.FullNode != nil && !s.FullNode.Synced {
+		return false
+	}
+	if s.Wallet != nil && !s.Wallet.Synced {
+		return false
+	}
+	return true
+}
+
+// Health checks which services are running and reachable, their versions, and the sync state of the full node and
+// wallet. Services are checked concurrently, and failures are reported in the HealthReport instead of returned
+// The websocket client must be in sync mode, since the responses are needed to build the report
+func (c *Client) Health(ctx context.Context, opts *HealthOptions, options ...rpcinterface.RequestOptionFunc) (*HealthReport, error) {
+	report := &HealthReport{
+		CheckedAt: time.Now().UTC(),
+	}
+
+	running := map[rpcinterface.ServiceType]bool{}
+	daemonReachable := false
+	response, _, err := c.DaemonService.RunningServices(withContextOption(ctx, options)...)
+	if err != nil {
+		report.DaemonError = err.Error()
+	} else {
+		daemonReachable = true
+		report.RunningServices = response.RunningServices
+		for _, name := range response.RunningServices {
+			if service, ok := name.ServiceType(); ok {
+				running[service] = true
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var services []rpcinterface.ServiceType
+	switch {
+	case opts != nil && len(opts.Services) > 0:
+		services = opts.Services
+	case daemonReachable:
+		for _, service := range HealthServices {
+			if running[service] {
+				services = append(services, service)
+			}
+		}
+	default:
+		services = HealthServices
+	}
+
+	report.Services = make([]ServiceHealth, len(services))
+	var wg sync.WaitGroup
+	for i, service := range services {
+		health := &report.Services[i]
+		health.Service = service.String()
+		if daemonReachable {
+			isRunning := running[service]
+			health.Running = &isRunning
+		}
+
+		wg.Add(1)
+		go func(service rpcinterface.ServiceType) {
+			defer wg.Done()
+			c.checkServiceHealth(ctx, service, health, options...)
+		}(service)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// checkServiceHealth fills in the health of a single service
+func (c *Client) checkServiceHealth(ctx context.Context, service rpcinterface.ServiceType, health *ServiceHealth, options ...rpcinterface.RequestOptionFunc) {
+	options = withContextOption(ctx, options)
+
+	s, err := c.versionService(service)
+	if err != nil {
+		health.Error = err.Error()
+		return
+	}
+	version, _, err := s.GetVersion(&GetVersionOptions{}, options...)
+	if err != nil {
+		health.Error = err.Error()
+		return
+	}
+	health.Reachable = true
+	health.Version = version.Version
+
+	switch service {
+	case rpcinterface.ServiceFullNode:
+		state, _, err := c.FullNodeService.GetBlockchainState(options...)
+		if err != nil {
+			health.Error = err.Error()
+			return
+		}
+		blockchainState := state.BlockchainState.OrEmpty()
+		health.FullNode = &FullNodeSyncState{
+			Synced:        blockchainState.Sync.Synced,
+			SyncMode:      blockchainState.Sync.SyncMode,
+			PeakHeight:    blockchainState.Peak.OrEmpty().Height,
+			SyncTipHeight: blockchainState.Sync.SyncTipHeight,
+		}
+	case rpcinterface.ServiceWallet:
+		status, _, err := c.WalletService.GetSyncStatus(options...)
+		if err != nil {
+			health.Error = err.Error()
+			return
+		}
+		height, _, err := c.WalletService.GetHeightInfo(options...)
+		if err != nil {
+			health.Error = err.Error()
+			return
+		}
+		health.Wallet = &WalletSyncState{
+			Synced:  status.Synced.OrEmpty(),
+			Syncing: status.Syncing.OrEmpty(),
+			Height:  height.Height.OrEmpty(),
+		}
+	}
+}
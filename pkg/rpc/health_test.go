@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+func TestHealth(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_version", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"success": true, "version": "2.4.3"}`)
+	})
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"success": true, "blockchain_state": {"peak": {"height": 100}, "sync": {"synced": true, "sync_tip_height": 100}}}`)
+	})
+	mux.HandleFunc("/get_sync_status", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"success": true, "synced": false, "syncing": true}`)
+	})
+	mux.HandleFunc("/get_height_info", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"success": true, "height": 90}`)
+	})
+
+	// Nothing is listening for the farmer
+	farmerURL, err := url.Parse("https://127.0.0.1:1")
+	require.NoError(t, err)
+	require.NoError(t, WithServiceURL(rpcinterface.ServiceFarmer, farmerURL)(client.activeClient))
+
+	report, err := client.Health(context.Background(), &HealthOptions{
+		Services: []rpcinterface.ServiceType{rpcinterface.ServiceFullNode, rpcinterface.ServiceWallet, rpcinterface.ServiceFarmer},
+	})
+	require.NoError(t, err)
+
+	// The daemon can't be reached in HTTP mode, so whether services are running is unknown
+	require.NotEmpty(t, report.DaemonError)
+	require.Nil(t, report.RunningServices)
+	require.Len(t, report.Services, 3)
+
+	fullNode := report.Services[0]
+	require.Equal(t, "full_node", fullNode.Service)
+	require.Nil(t, fullNode.Running)
+	require.True(t, fullNode.Reachable)
+	require.Equal(t, "2.4.3", fullNode.Version)
+	require.Equal(t, &FullNodeSyncState{Synced: true, PeakHeight: 100, SyncTipHeight: 100}, fullNode.FullNode)
+	require.True(t, fullNode.Ready())
+
+	wallet := report.Services[1]
+	require.True(t, wallet.Reachable)
+	require.Equal(t, &WalletSyncState{Syncing: true, Height: 90}, wallet.Wallet)
+	require.False(t, wallet.Ready())
+
+	farmer := report.Services[2]
+	require.False(t, farmer.Reachable)
+	require.NotEmpty(t, farmer.Error)
+	require.False(t, report.Ready())
+
+	b, err := json.Marshal(report)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"full_node":{"synced":true,"sync_mode":false,"peak_height":100,"sync_tip_height":100}`)
+}
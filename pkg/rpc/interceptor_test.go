@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+func TestWithInterceptors(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_wallet_balance", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "audit", r.Header.Get("X-Test"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "wallet_balance": {"wallet_id": 1}}`)
+	})
+
+	var calls []string
+	recorder := func(name string) rpcinterface.Interceptor {
+		return func(req *rpcinterface.Request, v rpcinterface.IResponse, next rpcinterface.Invoker) (*http.Response, error) {
+			calls = append(calls, name+":"+string(req.Endpoint))
+			resp, err := next(req, v)
+			calls = append(calls, fmt.Sprintf("%s:%t", name, v.IsSuccessful()))
+			return resp, err
+		}
+	}
+	headers := func(req *rpcinterface.Request, v rpcinterface.IResponse, next rpcinterface.Invoker) (*http.Response, error) {
+		require.Equal(t, rpcinterface.ServiceWallet, req.Service)
+		require.Equal(t, &GetWalletBalanceOptions{WalletID: 1}, req.Data)
+		req.Request.Header.Set("X-Test", "audit")
+		return next(req, v)
+	}
+	require.NoError(t, WithInterceptors(recorder("first"), recorder("second"), headers)(client.activeClient))
+
+	_, _, err := client.WalletService.GetWalletBalance(&GetWalletBalanceOptions{WalletID: 1})
+	require.NoError(t, err)
+	require.Equal(t, []string{"first:get_wallet_balance", "second:get_wallet_balance", "second:true", "first:true"}, calls)
+}
@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+// PageFunc fetches the next page of results for an Iterator
+// done is true once there are no more pages after this one. PageFuncs must only advance their position when the
+// page was fetched successfully, so that a failed page can be fetched again
+type PageFunc[T any] func(ctx context.Context, options ...rpcinterface.RequestOptionFunc) (items []T, done bool, err error)
+
+// Iterator transparently pages through the results of list style endpoints
+//
+//	it := client.FullNodeService.IterateBlocks(&rpc.IterateBlocksOptions{Start: 0, End: 1000})
+//	for it.Next(ctx) {
+//		block := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		// Everything returned by Value() before the error is valid. Calling Next again retries the failed page
+//	}
+type Iterator[T any] struct {
+	fetch   PageFunc[T]
+	options []rpcinterface.RequestOptionFunc
+
+	page    []T
+	index   int
+	current T
+	count   int
+	done    bool
+	err     error
+}
+
+// NewIterator returns an iterator that calls fetch whenever the current page is used up
+// The options are applied to every request the iterator makes
+func NewIterator[T any](fetch PageFunc[T], options ...rpcinterface.RequestOptionFunc) *Iterator[T] {
+	return &Iterator[T]{
+		fetch:   fetch,
+		options: options,
+	}
+}
+
+// Next advances to the next item, fetching the next page if required
+// Returns false when there are no more items, the context is cancelled, or an error occurs. Check Err() to tell these apart
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	it.err = nil
+	for it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		page, done, err := it.fetch(ctx, withContextOption(ctx, it.options)...)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = page
+		it.index = 0
+		it.done = done
+	}
+
+	it.current = it.page[it.index]
+	it.index++
+	it.count++
+	return true
+}
+
+// Value returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Count returns the number of items returned by the iterator so far
+func (it *Iterator[T]) Count() int {
+	return it.count
+}
+
+// Collect iterates through all remaining items and returns them
+// If an error occurs, the items collected up to that point are returned along with the error
+func (it *Iterator[T]) Collect(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+// errorIterator returns an iterator that stops with err without making any requests
+func errorIterator[T any](err error) *Iterator[T] {
+	return NewIterator(func(_ context.Context, _ ...rpcinterface.RequestOptionFunc) ([]T, bool, error) {
+		return nil, false, err
+	})
+}
+
+// clampPageSize returns size limited to maxSize, or defaultSize if size is not set
+func clampPageSize(size int, defaultSize int, maxSize int) int {
+	if size <= 0 {
+		return defaultSize
+	}
+	if size > maxSize {
+		return maxSize
+	}
+	return size
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+func TestIterateIPsAfterTimestamp(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	allIPs := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5"}
+	var calls atomic.Int32
+	mux.HandleFunc("/get_ips_after_timestamp", func(w http.ResponseWriter, r *http.Request) {
+		// Fail the second page once, to ensure progress isn't lost and the page can be fetched again
+		if calls.Add(1) == 2 {
+			_, _ = fmt.Fprint(w, `{"success": false, "error": "try again"}`)
+			return
+		}
+		opts := &GetIPsAfterTimestampOptions{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(opts))
+		end := min(int(opts.Offset+opts.Limit), len(allIPs))
+		page, err := json.Marshal(allIPs[opts.Offset:end])
+		assert.NoError(t, err)
+		_, _ = fmt.Fprintf(w, `{"success": true, "ips": %s, "total": %d}`, page, len(allIPs))
+	})
+
+	ctx := context.Background()
+	it := client.CrawlerService.IterateIPsAfterTimestamp(&IterateIPsAfterTimestampOptions{PageSize: 2})
+
+	ips, err := it.Collect(ctx)
+	require.Error(t, err)
+	require.Equal(t, allIPs[:2], ips)
+
+	rest, err := it.Collect(ctx)
+	require.NoError(t, err)
+	require.Equal(t, allIPs[2:], rest)
+	require.Equal(t, len(allIPs), it.Count())
+	require.Equal(t, int32(4), calls.Load())
+}
+
+func TestIteratorContextCancelled(t *testing.T) {
+	it := NewIterator(func(ctx context.Context, options ...rpcinterface.RequestOptionFunc) ([]int, bool, error) {
+		return []int{1}, false, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.True(t, it.Next(ctx))
+	cancel()
+	require.False(t, it.Next(ctx))
+	require.ErrorIs(t, it.Err(), context.Canceled)
+}
+
+func TestIteratorNilOptions(t *testing.T) {
+	_, server, client := setup(t)
+	defer teardown(server)
+
+	// Nil options fail when iterating instead of panicking
+	ctx := context.Background()
+	_, err := client.FullNodeService.IterateBlocks(nil).Collect(ctx)
+	require.Error(t, err)
+	_, err = client.FullNodeService.IterateCoinRecordsByPuzzleHashes(nil).Collect(ctx)
+	require.Error(t, err)
+	_, err = client.WalletService.IterateTransactions(nil).Collect(ctx)
+	require.Error(t, err)
+	_, err = client.CrawlerService.IterateIPsAfterTimestamp(nil).Collect(ctx)
+	require.Error(t, err)
+	_, err = client.DataLayerService.IterateKeysValues(nil).Collect(ctx)
+	require.Error(t, err)
+}
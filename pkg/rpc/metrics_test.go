@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/config"
+	"github.com/chia-network/go-chia-libs/pkg/metrics"
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+func TestWithMetrics(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	recorder := metrics.NewPrometheus("")
+	require.NoError(t, WithCache(time.Minute)(client.activeClient))
+	require.NoError(t, WithMetrics(recorder)(client.activeClient))
+
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true}`)
+	})
+	mux.HandleFunc("/push_tx", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": false, "error": "Failed to include transaction 0xabc, error DOUBLE_SPEND"}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		_, _, err := client.FullNodeService.GetBlockchainState()
+		require.NoError(t, err)
+	}
+	_, _, err := client.FullNodeService.PushTX(&FullNodePushTXOptions{})
+	require.Error(t, err)
+
+	buf := &bytes.Buffer{}
+	_, err = recorder.WriteTo(buf)
+	require.NoError(t, err)
+	out := buf.String()
+	require.Contains(t, out, `chia_rpc_requests_total{service="full_node",endpoint="get_blockchain_state",success="true",error_code=""} 2`)
+	require.Contains(t, out, `chia_rpc_requests_total{service="full_node",endpoint="push_tx",success="false",error_code="DOUBLE_SPEND"} 1`)
+	require.Contains(t, out, `chia_rpc_request_duration_seconds_count{service="full_node",endpoint="push_tx"} 1`)
+	require.Contains(t, out, `chia_rpc_cache_requests_total{result="hit"} 1`)
+	require.Contains(t, out, `chia_rpc_cache_requests_total{result="miss"} 1`)
+}
+
+func TestWithMetricsWebsocketSync(t *testing.T) {
+	mux, server, _ := setup(t)
+	defer teardown(server)
+
+	upgrader := websocket.Upgrader{}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		for {
+			request := &types.WebsocketRequest{}
+			if err := conn.ReadJSON(request); err != nil {
+				return
+			}
+			if request.Command != "push_tx" {
+				continue
+			}
+			assert.NoError(t, conn.WriteJSON(&types.WebsocketResponse{
+				Command:   request.Command,
+				RequestID: request.RequestID,
+				Data:      json.RawMessage(`{"success": false, "error": "Failed to include transaction 0xabc, error DOUBLE_SPEND"}`),
+			}))
+		}
+	})
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.ParseUint(serverURL.Port(), 10, 16)
+	require.NoError(t, err)
+
+	recorder := metrics.NewPrometheus("")
+	client, err := NewClient(ConnectionModeWebsocket,
+		WithManualConfig(config.ChiaConfig{
+			ChiaRoot:   tmpDir,
+			DaemonPort: uint16(port),
+			DaemonSSL:  config.SSLConfig{PrivateCRT: crtFilename, PrivateKey: keyFilename},
+		}),
+		WithBaseURL(&url.URL{Host: serverURL.Hostname()}),
+		WithMetrics(recorder),
+	)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+	client.SetSyncMode()
+
+	// The websocket client returns no http.Response or ChiaRPCError, so the failure is taken from the decoded response
+	response, _, err := client.FullNodeService.PushTX(&FullNodePushTXOptions{})
+	require.NoError(t, err)
+	require.False(t, response.IsSuccessful())
+
+	buf := &bytes.Buffer{}
+	_, err = recorder.WriteTo(buf)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), `chia_rpc_requests_total{service="full_node",endpoint="push_tx",success="false",error_code="DOUBLE_SPEND"} 1`)
+}
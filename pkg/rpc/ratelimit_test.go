@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/publichttpclient"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var lock sync.Mutex
+	calls := map[string]int{}
+	hit := func(endpoint string) int {
+		lock.Lock()
+		defer lock.Unlock()
+		calls[endpoint]++
+		return calls[endpoint]
+	}
+	count := func(endpoint string) int {
+		lock.Lock()
+		defer lock.Unlock()
+		return calls[endpoint]
+	}
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		if hit("get_blockchain_state") == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"success": true}`)
+	})
+	mux.HandleFunc("/get_network_info", func(w http.ResponseWriter, r *http.Request) {
+		hit("get_network_info")
+		_, _ = fmt.Fprint(w, `{"success": true}`)
+	})
+	mux.HandleFunc("/get_fee_estimate", func(w http.ResponseWriter, r *http.Request) {
+		hit("get_fee_estimate")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/get_block", func(w http.ResponseWriter, r *http.Request) {
+		hit("get_block")
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	logs := &bytes.Buffer{}
+	client, err := NewClient(ConnectionModePublicHTTP, WithPublicConfig(), WithBaseURL(serverURL),
+		WithLogHandler(slog.NewTextHandler(logs, nil)),
+		WithRateLimit(&publichttpclient.RateLimitPolicy{
+			Endpoints: map[rpcinterface.Endpoint]publichttpclient.RateLimit{
+				"get_network_info": {Rate: 20, Burst: 1},
+			},
+			MaxRetryAfter: time.Second,
+		}),
+	)
+	require.NoError(t, err)
+
+	// Retried after the Retry-After wait
+	_, _, err = client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, 2, count("get_blockchain_state"))
+	require.Contains(t, logs.String(), "Request throttled by server")
+
+	// Client side token bucket
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, _, err = client.FullNodeService.GetNetworkInfo(&GetNetworkInfoOptions{})
+		require.NoError(t, err)
+	}
+	require.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	// 503 is left to the retry policy, which isn't set
+	_, resp, _ := client.FullNodeService.GetFeeEstimate(&GetFeeEstimateOptions{})
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, 1, count("get_fee_estimate"))
+
+	// Waits longer than MaxRetryAfter return the throttled response, without pausing other requests
+	_, resp, _ = client.FullNodeService.GetBlock(&GetBlockOptions{})
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.Equal(t, 1, count("get_block"))
+	start = time.Now()
+	_, _, err = client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestThrottleByDefault(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var stateCalls, blockCalls, pushCalls atomic.Int32
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		if stateCalls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"success": true}`)
+	})
+	mux.HandleFunc("/get_block", func(w http.ResponseWriter, r *http.Request) {
+		if blockCalls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"success": true}`)
+	})
+	mux.HandleFunc("/push_tx", func(w http.ResponseWriter, r *http.Request) {
+		pushCalls.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client, err := NewClient(ConnectionModePublicHTTP, WithPublicConfig(), WithBaseURL(serverURL))
+	require.NoError(t, err)
+
+	_, _, err = client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, int32(2), stateCalls.Load())
+
+	// 503 with Retry-After is retried for read-only endpoints
+	_, _, err = client.FullNodeService.GetBlock(&GetBlockOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), blockCalls.Load())
+
+	// But not for mutating endpoints, since the request might have been processed
+	_, resp, _ := client.FullNodeService.PushTX(&FullNodePushTXOptions{})
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, int32(1), pushCalls.Load())
+}
@@ -0,0 +1,22 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+)
+
+// WithContext runs the request with the provided context
+// Cancelling the context or reaching its deadline aborts the RPC call
+func WithContext(ctx context.Context) rpcinterface.RequestOptionFunc {
+	return func(req *rpcinterface.Request) error {
+		*req = *req.WithContext(ctx)
+		return nil
+	}
+}
+
+// withContextOption returns a copy of options with WithContext(ctx) added last, so it takes precedence
+// A copy is always made so that concurrent callers never share the backing array
+func withContextOption(ctx context.Context, options []rpcinterface.RequestOptionFunc) []rpcinterface.RequestOptionFunc {
+	return append(append([]rpcinterface.RequestOptionFunc{}, options...), WithContext(ctx))
+}
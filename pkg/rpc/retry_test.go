@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/httpclient"
+)
+
+func TestWithRetryPolicy(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	policy := httpclient.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	require.NoError(t, WithRetryPolicy(policy)(client.activeClient))
+
+	var stateCalls atomic.Int32
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		if stateCalls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true}`)
+	})
+
+	var sendCalls atomic.Int32
+	mux.HandleFunc("/send_transaction", func(w http.ResponseWriter, r *http.Request) {
+		sendCalls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, _, err := client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, int32(3), stateCalls.Load())
+
+	// Mutating endpoints must never be sent twice
+	_, resp, _ := client.WalletService.SendTransaction(&SendTransactionOptions{})
+	require.NotNil(t, resp)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, int32(1), sendCalls.Load())
+}
+
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	policy := httpclient.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	require.NoError(t, WithRetryPolicy(policy)(client.activeClient))
+
+	var stateCalls, infoCalls atomic.Int32
+	mux.HandleFunc("/get_blockchain_state", func(w http.ResponseWriter, r *http.Request) {
+		if stateCalls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true}`)
+	})
+	mux.HandleFunc("/get_network_info", func(w http.ResponseWriter, r *http.Request) {
+		infoCalls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	// Waits as long as the server asks, instead of the backoff
+	start := time.Now()
+	_, _, err := client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, int32(2), stateCalls.Load())
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	// Waits longer than MaxBackoff return the response
+	_, resp, _ := client.FullNodeService.GetNetworkInfo(&GetNetworkInfoOptions{})
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, int32(1), infoCalls.Load())
+}
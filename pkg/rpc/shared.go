@@ -1,11 +1,191 @@
 package rpc
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
 	"github.com/samber/mo"
 
 	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/types"
 )
 
+// SharedService encapsulates the RPC methods every chia RPC server has
+// It is embedded in each service, so the methods are available as, for example, client.FarmerService.GetRoutes()
+type SharedService struct {
+	client  *Client
+	service rpcinterface.ServiceType
+}
+
+// NewRequest returns a new request specific to the service
+func (s *SharedService) NewRequest(rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
+	return s.client.NewRequest(s.service, rpcEndpoint, opt)
+}
+
+// GetClient returns the active client for the service
+func (s *SharedService) GetClient() rpcinterface.Client {
+	return s.client
+}
+
+// GetConnectionsOptions options to filter get_connections
+type GetConnectionsOptions struct {
+	NodeType types.NodeType `json:"node_type,omitempty"`
+}
+
+// GetConnectionsResponse get_connections response format
+type GetConnectionsResponse struct {
+	rpcinterface.Response
+	Connections mo.Option[[]types.Connection] `json:"connections"`
+}
+
+// GetConnections returns connections
+func (s *SharedService) GetConnections(opts *GetConnectionsOptions, options ...rpcinterface.RequestOptionFunc) (*GetConnectionsResponse, *http.Response, error) {
+	return Do(s, "get_connections", opts, &GetConnectionsResponse{}, options...)
+}
+
+// OpenConnectionOptions options for open_connection
+type OpenConnectionOptions struct {
+	Host string `json:"host"`
+	Port uint16 `json:"port"`
+}
+
+// OpenConnectionResponse response from open_connection
+type OpenConnectionResponse struct {
+	rpcinterface.Response
+}
+
+// OpenConnection connects the service to a peer
+func (s *SharedService) OpenConnection(opts *OpenConnectionOptions, options ...rpcinterface.RequestOptionFunc) (*OpenConnectionResponse, *http.Response, error) {
+	return Do(s, "open_connection", opts, &OpenConnectionResponse{}, options...)
+}
+
+// CloseConnectionOptions options for close_connection
+type CloseConnectionOptions struct {
+	NodeID types.Bytes32 `json:"node_id"`
+}
+
+// CloseConnectionResponse response from close_connection
+type CloseConnectionResponse struct {
+	rpcinterface.Response
+}
+
+// CloseConnection disconnects the service from the peer with the node ID
+func (s *SharedService) CloseConnection(opts *CloseConnectionOptions, options ...rpcinterface.RequestOptionFunc) (*CloseConnectionResponse, *http.Response, error) {
+	return Do(s, "close_connection", opts, &CloseConnectionResponse{}, options...)
+}
+
+// GetNetworkInfo gets the network name and prefix from the service
+func (s *SharedService) GetNetworkInfo(opts *GetNetworkInfoOptions, options ...rpcinterface.RequestOptionFunc) (*GetNetworkInfoResponse, *http.Response, error) {
+	return Do(s, "get_network_info", opts, &GetNetworkInfoResponse{}, options...)
+}
+
+// GetVersion returns the application version for the service
+func (s *SharedService) GetVersion(opts *GetVersionOptions, options ...rpcinterface.RequestOptionFunc) (*GetVersionResponse, *http.Response, error) {
+	return Do(s, "get_version", opts, &GetVersionResponse{}, options...)
+}
+
+// GetRoutesResponse response from get_routes
+type GetRoutesResponse struct {
+	rpcinterface.Response
+	Routes mo.Option[[]string] `json:"routes"`
+}
+
+// GetRoutes returns the endpoints the service supports
+func (s *SharedService) GetRoutes(options ...rpcinterface.RequestOptionFunc) (*GetRoutesResponse, *http.Response, error) {
+	return Do(s, "get_routes", nil, &GetRoutesResponse{}, options...)
+}
+
+// HealthzResponse response from healthz
+// chia returns success as the string "true" from this endpoint, so it is decoded separately from rpcinterface.Response
+type HealthzResponse struct {
+	Success bool              `json:"success"`
+	Error   mo.Option[string] `json:"error,omitempty"`
+}
+
+// UnmarshalJSON accepts success as either a bool or a string
+func (r *HealthzResponse) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Success json.RawMessage   `json:"success"`
+		Error   mo.Option[string] `json:"error"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.Error = raw.Error
+
+	switch string(raw.Success) {
+	case "true", `"true"`:
+		r.Success = true
+	case "", "null", "false", `"false"`:
+		r.Success = false
+	default:
+		return fmt.Errorf("invalid healthz success value %s", raw.Success)
+	}
+	return nil
+}
+
+// IsSuccessful returns whether the service reported itself healthy
+func (r *HealthzResponse) IsSuccessful() bool {
+	return r.Success
+}
+
+// GetRPCError returns the error if present or an empty string
+func (r *HealthzResponse) GetRPCError() string {
+	return r.Error.OrEmpty()
+}
+
+// Healthz checks that the service is up and responding to requests
+func (s *SharedService) Healthz(options ...rpcinterface.RequestOptionFunc) (*HealthzResponse, *http.Response, error) {
+	return Do(s, "healthz", nil, &HealthzResponse{}, options...)
+}
+
+// StopNodeResponse response from stop_node
+type StopNodeResponse struct {
+	rpcinterface.Response
+}
+
+// StopNode shuts down the service
+func (s *SharedService) StopNode(options ...rpcinterface.RequestOptionFunc) (*StopNodeResponse, *http.Response, error) {
+	return Do(s, "stop_node", nil, &StopNodeResponse{}, options...)
+}
+
+// LogLevelResponse response from get_log_level and reset_log_level
+type LogLevelResponse struct {
+	rpcinterface.Response
+	Level           mo.Option[string]   `json:"level"`
+	AvailableLevels mo.Option[[]string] `json:"available_levels"`
+}
+
+// GetLogLevel returns the current log level of the service
+func (s *SharedService) GetLogLevel(options ...rpcinterface.RequestOptionFunc) (*LogLevelResponse, *http.Response, error) {
+	return Do(s, "get_log_level", nil, &LogLevelResponse{}, options...)
+}
+
+// SetLogLevelOptions options for set_log_level
+type SetLogLevelOptions struct {
+	// Level is a python log level, such as DEBUG, INFO or WARNING
+	Level string `json:"level"`
+}
+
+// SetLogLevelResponse response from set_log_level
+type SetLogLevelResponse struct {
+	rpcinterface.Response
+	Level           mo.Option[string]   `json:"level"`
+	AvailableLevels mo.Option[[]string] `json:"available_levels"`
+	Errors          mo.Option[[]string] `json:"errors"`
+}
+
+// SetLogLevel changes the log level of the service until it restarts or reset_log_level is called
+func (s *SharedService) SetLogLevel(opts *SetLogLevelOptions, options ...rpcinterface.RequestOptionFunc) (*SetLogLevelResponse, *http.Response, error) {
+	return Do(s, "set_log_level", opts, &SetLogLevelResponse{}, options...)
+}
+
+// ResetLogLevel sets the log level of the service back to the level in its config
+func (s *SharedService) ResetLogLevel(options ...rpcinterface.RequestOptionFunc) (*LogLevelResponse, *http.Response, error) {
+	return Do(s, "reset_log_level", nil, &LogLevelResponse{}, options...)
+}
+
 // GetNetworkInfoOptions options for the get_network_info rpc calls
 type GetNetworkInfoOptions struct{}
 
@@ -68,3 +248,26 @@ const (
 	// ServiceFullNameCrawler name of the crawler service
 	ServiceFullNameCrawler ServiceFullName = "chia_crawler"
 )
+
+// ServiceType returns the RPC service for the full service name, if it has an RPC server this client can connect to
+func (n ServiceFullName) ServiceType() (rpcinterface.ServiceType, bool) {
+	switch n {
+	case ServiceFullNameDaemon:
+		return rpcinterface.ServiceDaemon, true
+	case ServiceFullNameNode, ServiceFullNameSimulator:
+		return rpcinterface.ServiceFullNode, true
+	case ServiceFullNameWallet:
+		return rpcinterface.ServiceWallet, true
+	case ServiceFullNameFarmer:
+		return rpcinterface.ServiceFarmer, true
+	case ServiceFullNameHarvester:
+		return rpcinterface.ServiceHarvester, true
+	case ServiceFullNameTimelord:
+		return rpcinterface.ServiceTimelord, true
+	case ServiceFullNameCrawler:
+		return rpcinterface.ServiceCrawler, true
+	case ServiceFullNameDataLayer:
+		return rpcinterface.ServiceDataLayer, true
+	}
+	return 0, false
+}
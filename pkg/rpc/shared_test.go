@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedEndpoints(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_routes", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"success": true, "routes": ["/get_routes", "/healthz", "/get_version"]}`)
+	})
+	mux.HandleFunc("/set_log_level", func(w http.ResponseWriter, r *http.Request) {
+		opts := &SetLogLevelOptions{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(opts))
+		assert.Equal(t, "DEBUG", opts.Level)
+		_, _ = fmt.Fprint(w, `{"success": true, "level": "DEBUG", "available_levels": ["CRITICAL", "ERROR", "WARNING", "INFO", "DEBUG", "NOTSET"], "errors": []}`)
+	})
+
+	// The shared endpoints are available on every service, including those with no endpoints of their own
+	routes, _, err := client.TimelordService.GetRoutes()
+	require.NoError(t, err)
+	require.Contains(t, routes.Routes.MustGet(), "/healthz")
+
+	level, _, err := client.HarvesterService.SetLogLevel(&SetLogLevelOptions{Level: "DEBUG"})
+	require.NoError(t, err)
+	require.Equal(t, "DEBUG", level.Level.MustGet())
+	require.Len(t, level.AvailableLevels.MustGet(), 6)
+}
+
+func TestHealthz(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	healthy := true
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if healthy {
+			// chia returns success as a string from healthz
+			_, _ = fmt.Fprint(w, `{"success": "true"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"success": false, "error": "shutting down"}`)
+	})
+
+	response, _, err := client.FarmerService.Healthz()
+	require.NoError(t, err)
+	require.True(t, response.Success)
+
+	healthy = false
+	_, _, err = client.CrawlerService.Healthz()
+	require.EqualError(t, err, "shutting down")
+}
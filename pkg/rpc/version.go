@@ -0,0 +1,250 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/websocketclient"
+)
+
+// ErrUnsupportedByNode is returned when a request needs a newer version of chia than the service is running
+var ErrUnsupportedByNode = errors.New("unsupported by node")
+
+// ErrVersionUnknown is returned by ServiceVersion when the version can't be looked up, such as in async websocket mode
+// where the get_version response would be delivered to the websocket handlers
+var ErrVersionUnknown = errors.New("version is unknown")
+
+// VersionErrorTTL is how long a failed version lookup is cached before get_version is tried again
+const VersionErrorTTL = 30 * time.Second
+
+// Minimum versions of chia-blockchain for endpoints and options that were added after the initial release
+var (
+	// MinVersionExcludeReorged is the first version where get_blocks supports exclude_reorged
+	MinVersionExcludeReorged = MustParseVersion("1.2.6")
+
+	// MinVersionFeeEstimate is the first version with the get_fee_estimate endpoint
+	MinVersionFeeEstimate = MustParseVersion("1.6.1")
+
+	// MinVersionMempoolItemsByCoinName is the first version with the get_mempool_items_by_coin_name endpoint
+	MinVersionMempoolItemsByCoinName = MustParseVersion("2.1.2")
+)
+
+// versionPattern matches chia version strings such as 2.4.3, 2.4.4rc1 and 2.5.0.dev12
+var versionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?(.*)$`)
+
+// preReleasePattern splits a pre-release such as rc10 into its name and number
+var preReleasePattern = regexp.MustCompile(`^(\D*)(\d+)$`)
+
+// Version is a chia-blockchain version, as reported by get_version
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+
+	// PreRelease is anything after the version number, such as rc1 or .dev12
+	// Pre-releases are ordered before the release with the same version number, and by number within the same name,
+	// so rc10 is newer than rc2
+	PreRelease string
+}
+
+// ParseVersion parses a chia version string
+func ParseVersion(version string) (Version, error) {
+	match := versionPattern.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil {
+		return Version{}, fmt.Errorf("invalid chia version %q", version)
+	}
+	v := Version{PreRelease: strings.TrimLeft(match[4], ".-+")}
+	var err error
+	if v.Major, err = strconv.Atoi(match[1]); err != nil {
+		return Version{}, fmt.Errorf("invalid chia version %q: %w", version, err)
+	}
+	if v.Minor, err = strconv.Atoi(match[2]); err != nil {
+		return Version{}, fmt.Errorf("invalid chia version %q: %w", version, err)
+	}
+	if match[3] != "" {
+		if v.Patch, err = strconv.Atoi(match[3]); err != nil {
+			return Version{}, fmt.Errorf("invalid chia version %q: %w", version, err)
+		}
+	}
+	return v, nil
+}
+
+// MustParseVersion parses a chia version string and panics if it is invalid
+func MustParseVersion(version string) Version {
+	v, err := ParseVersion(version)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns the version in the format chia reports it
+func (v Version) String() string {
+	version := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease == "" {
+		return version
+	}
+	if strings.HasPrefix(v.PreRelease, "dev") {
+		return fmt.Sprintf("%s.%s", version, v.PreRelease)
+	}
+	return version + v.PreRelease
+}
+
+// Compare returns -1 if v is older than other, 1 if v is newer, and 0 if they are the same version
+func (v Version) Compare(other Version) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	switch {
+	case v.PreRelease == other.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case other.PreRelease == "":
+		return -1
+	}
+	return comparePreRelease(v.PreRelease, other.PreRelease)
+}
+
+// comparePreRelease compares pre-releases with the same name by number, and anything else as strings
+func comparePreRelease(a, b string) int {
+	matchA := preReleasePattern.FindStringSubmatch(a)
+	matchB := preReleasePattern.FindStringSubmatch(b)
+	if matchA != nil && matchB != nil && matchA[1] == matchB[1] {
+		numA, errA := strconv.Atoi(matchA[2])
+		numB, errB := strconv.Atoi(matchB[2])
+		if errA == nil && errB == nil {
+			switch {
+			case numA < numB:
+				return -1
+			case numA > numB:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// AtLeast returns true if v is the same as or newer than minimum
+func (v Version) AtLeast(minimum Version) bool {
+	return v.Compare(minimum) >= 0
+}
+
+// versionLookup is the cached result of get_version for a service
+type versionLookup struct {
+	version Version
+
+	// err is set when get_version failed, and is cached until expires so a failing service isn't asked on every call
+	err     error
+	expires time.Time
+}
+
+// ServiceVersion returns the version of chia the service is running
+// The version is fetched with get_version the first time it is needed, and cached until ClearVersionCache is called
+// or the websocket client reconnects. Failures are cached for VersionErrorTTL
+// In async websocket mode, the version can't be looked up and an error matching ErrVersionUnknown is returned
+func (c *Client) ServiceVersion(service rpcinterface.ServiceType, options ...rpcinterface.RequestOptionFunc) (Version, error) {
+	return c.lookupVersion(service, options...)
+}
+
+// RequireVersion returns an error matching ErrUnsupportedByNode if the service is older than minimum
+// If the version can't be determined, such as when a public RPC server doesn't support get_version or in async
+// websocket mode, no error is returned and the request should be sent anyway
+func (c *Client) RequireVersion(service rpcinterface.ServiceType, minimum Version, feature string, options ...rpcinterface.RequestOptionFunc) error {
+	version, err := c.lookupVersion(service, options...)
+	if err != nil {
+		return nil
+	}
+	if !version.AtLeast(minimum) {
+		return fmt.Errorf("%w: %s requires %s %s or newer, but it is running %s", ErrUnsupportedByNode, feature, service, minimum, version)
+	}
+	return nil
+}
+
+// ClearVersionCache forgets the cached service versions, so they are fetched again, such as after upgrading chia
+func (c *Client) ClearVersionCache() {
+	c.versionsLock.Lock()
+	defer c.versionsLock.Unlock()
+	c.versions = nil
+}
+
+// lookupVersion returns the cached version of the service, calling get_version if it isn't cached yet
+func (c *Client) lookupVersion(service rpcinterface.ServiceType, options ...rpcinterface.RequestOptionFunc) (Version, error) {
+	// In async mode the get_version response would be delivered to the websocket handlers, so it is never sent
+	if ws, ok := c.activeClient.(*websocketclient.WebsocketClient); ok && !ws.SyncMode() {
+		return Version{}, fmt.Errorf("%w: %s version can't be looked up in async websocket mode", ErrVersionUnknown, service)
+	}
+
+	c.versionsLock.Lock()
+	lookup, ok := c.versions[service]
+	c.versionsLock.Unlock()
+	if ok && (lookup.err == nil || time.Now().Before(lookup.expires)) {
+		return lookup.version, lookup.err
+	}
+
+	s, err := c.versionService(service)
+	if err != nil {
+		return Version{}, err
+	}
+	lookup = versionLookup{}
+	response, _, err := s.GetVersion(&GetVersionOptions{}, options...)
+	if err == nil {
+		lookup.version, err = ParseVersion(response.Version)
+	}
+	if err != nil {
+		// The caller giving up says nothing about the service, so only the service's own failures are cached
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return Version{}, err
+		}
+		lookup = versionLookup{err: err, expires: time.Now().Add(VersionErrorTTL)}
+	}
+
+	c.versionsLock.Lock()
+	defer c.versionsLock.Unlock()
+	if c.versions == nil {
+		c.versions = map[rpcinterface.ServiceType]versionLookup{}
+	}
+	c.versions[service] = lookup
+	return lookup.version, lookup.err
+}
+
+// versionService is implemented by every service
+type versionService interface {
+	GetVersion(opts *GetVersionOptions, options ...rpcinterface.RequestOptionFunc) (*GetVersionResponse, *http.Response, error)
+}
+
+// versionService returns the service for the service type
+func (c *Client) versionService(service rpcinterface.ServiceType) (versionService, error) {
+	switch service {
+	case rpcinterface.ServiceDaemon:
+		return c.DaemonService, nil
+	case rpcinterface.ServiceFullNode:
+		return c.FullNodeService, nil
+	case rpcinterface.ServiceWallet:
+		return c.WalletService, nil
+	case rpcinterface.ServiceFarmer:
+		return c.FarmerService, nil
+	case rpcinterface.ServiceHarvester:
+		return c.HarvesterService, nil
+	case rpcinterface.ServiceCrawler:
+		return c.CrawlerService, nil
+	case rpcinterface.ServiceDataLayer:
+		return c.DataLayerService, nil
+	case rpcinterface.ServiceTimelord:
+		return c.TimelordService, nil
+	}
+	return nil, fmt.Errorf("unknown service %s", service)
+}
@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/websocketclient"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected Version
+	}{
+		{"2.4.3", Version{Major: 2, Minor: 4, Patch: 3}},
+		{"2.4.4rc1", Version{Major: 2, Minor: 4, Patch: 4, PreRelease: "rc1"}},
+		{"2.5.0.dev12", Version{Major: 2, Minor: 5, PreRelease: "dev12"}},
+		{"1.8", Version{Major: 1, Minor: 8}},
+	}
+	for _, test := range tests {
+		v, err := ParseVersion(test.version)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, v)
+		if test.version != "1.8" {
+			require.Equal(t, test.version, v.String())
+		}
+	}
+
+	_, err := ParseVersion("unknown")
+	require.Error(t, err)
+
+	require.True(t, MustParseVersion("2.4.4").AtLeast(MustParseVersion("2.4.4")))
+	require.True(t, MustParseVersion("2.4.4").AtLeast(MustParseVersion("2.4.4rc1")))
+	require.True(t, MustParseVersion("2.4.4rc2").AtLeast(MustParseVersion("2.4.4rc1")))
+	require.True(t, MustParseVersion("2.4.4rc10").AtLeast(MustParseVersion("2.4.4rc2")))
+	require.False(t, MustParseVersion("2.4.4rc2").AtLeast(MustParseVersion("2.4.4rc10")))
+	require.True(t, MustParseVersion("2.5.0.dev12").AtLeast(MustParseVersion("2.5.0.dev9")))
+	require.True(t, MustParseVersion("2.10.0").AtLeast(MustParseVersion("2.9.1")))
+	require.False(t, MustParseVersion("2.4.4rc1").AtLeast(MustParseVersion("2.4.4")))
+	require.False(t, MustParseVersion("1.6.0").AtLeast(MinVersionFeeEstimate))
+}
+
+func TestRequireVersion(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	var versionCalls atomic.Int32
+	var version atomic.Value
+	version.Store("1.5.1")
+	mux.HandleFunc("/get_version", func(w http.ResponseWriter, r *http.Request) {
+		versionCalls.Add(1)
+		_, _ = fmt.Fprintf(w, `{"success": true, "version": "%s"}`, version.Load())
+	})
+	var feeCalls atomic.Int32
+	mux.HandleFunc("/get_fee_estimate", func(w http.ResponseWriter, r *http.Request) {
+		feeCalls.Add(1)
+		_, _ = fmt.Fprint(w, `{"success": true, "estimates": [0]}`)
+	})
+
+	// Fails without sending the request to an old node
+	_, _, err := client.FullNodeService.GetFeeEstimate(&GetFeeEstimateOptions{TargetTimes: []uint64{60}})
+	require.ErrorIs(t, err, ErrUnsupportedByNode)
+	require.Equal(t, int32(0), feeCalls.Load())
+
+	// The version is cached
+	v, err := client.ServiceVersion(rpcinterface.ServiceFullNode)
+	require.NoError(t, err)
+	require.Equal(t, MustParseVersion("1.5.1"), v)
+	require.Equal(t, int32(1), versionCalls.Load())
+
+	// After an upgrade, the cache has to be cleared to see the new version
+	version.Store("2.4.3")
+	client.ClearVersionCache()
+	_, _, err = client.FullNodeService.GetFeeEstimate(&GetFeeEstimateOptions{TargetTimes: []uint64{60}})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), feeCalls.Load())
+	require.Equal(t, int32(2), versionCalls.Load())
+
+	// Unknown versions don't block requests, since the node may support them
+	version.Store("unknown")
+	require.NoError(t, client.RequireVersion(rpcinterface.ServiceWallet, MinVersionFeeEstimate, "test"))
+	_, err = client.ServiceVersion(rpcinterface.ServiceWallet)
+	require.Error(t, err)
+
+	// Failures are cached, so get_version isn't called on every request
+	require.Equal(t, int32(3), versionCalls.Load())
+
+	// Once the failure expires, the version is fetched again
+	version.Store("2.4.3")
+	client.versionsLock.Lock()
+	lookup := client.versions[rpcinterface.ServiceWallet]
+	lookup.expires = time.Now().Add(-time.Second)
+	client.versions[rpcinterface.ServiceWallet] = lookup
+	client.versionsLock.Unlock()
+	v, err = client.ServiceVersion(rpcinterface.ServiceWallet)
+	require.NoError(t, err)
+	require.Equal(t, MustParseVersion("2.4.3"), v)
+	require.Equal(t, int32(4), versionCalls.Load())
+}
+
+func TestRequireVersionAsyncWebsocket(t *testing.T) {
+	// In async mode the get_version response would reach the websocket handlers, so the version is never requested
+	client := &Client{activeClient: &websocketclient.WebsocketClient{}}
+	_, err := client.ServiceVersion(rpcinterface.ServiceFullNode)
+	require.ErrorIs(t, err, ErrVersionUnknown)
+	require.NoError(t, client.RequireVersion(rpcinterface.ServiceFullNode, MinVersionFeeEstimate, "test"))
+}
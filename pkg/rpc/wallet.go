@@ -1,6 +1,10 @@
 package rpc
 
 import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/samber/mo"
@@ -11,32 +15,7 @@ import (
 
 // WalletService encapsulates wallet RPC methods
 type WalletService struct {
-	client *Client
-}
-
-// NewRequest returns a new request specific to the wallet service
-func (s *WalletService) NewRequest(rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
-	return s.client.NewRequest(rpcinterface.ServiceWallet, rpcEndpoint, opt)
-}
-
-// GetClient returns the active client for the service
-func (s *WalletService) GetClient() rpcinterface.Client {
-	return s.client
-}
-
-// GetConnections returns connections
-func (s *WalletService) GetConnections(opts *GetConnectionsOptions) (*GetConnectionsResponse, *http.Response, error) {
-	return Do(s, "get_connections", opts, &GetConnectionsResponse{})
-}
-
-// GetNetworkInfo wallet rpc -> get_network_info
-func (s *WalletService) GetNetworkInfo(opts *GetNetworkInfoOptions) (*GetNetworkInfoResponse, *http.Response, error) {
-	return Do(s, "get_network_info", opts, &GetNetworkInfoResponse{})
-}
-
-// GetVersion returns the application version for the service
-func (s *WalletService) GetVersion(opts *GetVersionOptions) (*GetVersionResponse, *http.Response, error) {
-	return Do(s, "get_version", opts, &GetVersionResponse{})
+	SharedService
 }
 
 // GetPublicKeysResponse response from get_public_keys
@@ -46,8 +25,8 @@ type GetPublicKeysResponse struct {
 }
 
 // GetPublicKeys endpoint
-func (s *WalletService) GetPublicKeys() (*GetPublicKeysResponse, *http.Response, error) {
-	return Do(s, "get_public_keys", nil, &GetPublicKeysResponse{})
+func (s *WalletService) GetPublicKeys(options ...rpcinterface.RequestOptionFunc) (*GetPublicKeysResponse, *http.Response, error) {
+	return Do(s, "get_public_keys", nil, &GetPublicKeysResponse{}, options...)
 }
 
 // GenerateMnemonicResponse Random new 24 words response
@@ -57,8 +36,8 @@ type GenerateMnemonicResponse struct {
 }
 
 // GenerateMnemonic Endpoint for generating a new random 24 words
-func (s *WalletService) GenerateMnemonic() (*GenerateMnemonicResponse, *http.Response, error) {
-	return Do(s, "generate_mnemonic", nil, &GenerateMnemonicResponse{})
+func (s *WalletService) GenerateMnemonic(options ...rpcinterface.RequestOptionFunc) (*GenerateMnemonicResponse, *http.Response, error) {
+	return Do(s, "generate_mnemonic", nil, &GenerateMnemonicResponse{}, options...)
 }
 
 // AddKeyOptions options for the add_key endpoint
@@ -74,8 +53,8 @@ type AddKeyResponse struct {
 }
 
 // AddKey Adds a new key from 24 words to the keychain
-func (s *WalletService) AddKey(opts *AddKeyOptions) (*AddKeyResponse, *http.Response, error) {
-	return Do(s, "add_key", opts, &AddKeyResponse{})
+func (s *WalletService) AddKey(opts *AddKeyOptions, options ...rpcinterface.RequestOptionFunc) (*AddKeyResponse, *http.Response, error) {
+	return Do(s, "add_key", opts, &AddKeyResponse{}, options...)
 }
 
 // DeleteAllKeysResponse Delete keys response
@@ -84,8 +63,8 @@ type DeleteAllKeysResponse struct {
 }
 
 // DeleteAllKeys deletes all keys from the keychain
-func (s *WalletService) DeleteAllKeys() (*DeleteAllKeysResponse, *http.Response, error) {
-	return Do(s, "delete_all_keys", nil, &DeleteAllKeysResponse{})
+func (s *WalletService) DeleteAllKeys(options ...rpcinterface.RequestOptionFunc) (*DeleteAllKeysResponse, *http.Response, error) {
+	return Do(s, "delete_all_keys", nil, &DeleteAllKeysResponse{}, options...)
 }
 
 // GetNextAddressOptions options for get_next_address endpoint
@@ -102,8 +81,8 @@ type GetNextAddressResponse struct {
 }
 
 // GetNextAddress returns the current address for the wallet. If NewAddress is true, it moves to the next address before responding
-func (s *WalletService) GetNextAddress(opts *GetNextAddressOptions) (*GetNextAddressResponse, *http.Response, error) {
-	return Do(s, "get_next_address", opts, &GetNextAddressResponse{})
+func (s *WalletService) GetNextAddress(opts *GetNextAddressOptions, options ...rpcinterface.RequestOptionFunc) (*GetNextAddressResponse, *http.Response, error) {
+	return Do(s, "get_next_address", opts, &GetNextAddressResponse{}, options...)
 }
 
 // GetWalletSyncStatusResponse Response for get_sync_status on wallet
@@ -115,8 +94,8 @@ type GetWalletSyncStatusResponse struct {
 }
 
 // GetSyncStatus wallet rpc -> get_sync_status
-func (s *WalletService) GetSyncStatus() (*GetWalletSyncStatusResponse, *http.Response, error) {
-	return Do(s, "get_sync_status", nil, &GetWalletSyncStatusResponse{})
+func (s *WalletService) GetSyncStatus(options ...rpcinterface.RequestOptionFunc) (*GetWalletSyncStatusResponse, *http.Response, error) {
+	return Do(s, "get_sync_status", nil, &GetWalletSyncStatusResponse{}, options...)
 }
 
 // GetWalletHeightInfoResponse response for get_height_info on wallet
@@ -126,13 +105,20 @@ type GetWalletHeightInfoResponse struct {
 }
 
 // GetHeightInfo wallet rpc -> get_height_info
-func (s *WalletService) GetHeightInfo() (*GetWalletHeightInfoResponse, *http.Response, error) {
-	return Do(s, "get_height_info", nil, &GetWalletHeightInfoResponse{})
+func (s *WalletService) GetHeightInfo(options ...rpcinterface.RequestOptionFunc) (*GetWalletHeightInfoResponse, *http.Response, error) {
+	return Do(s, "get_height_info", nil, &GetWalletHeightInfoResponse{}, options...)
 }
 
 // GetWalletsOptions wallet rpc -> get_wallets
 type GetWalletsOptions struct {
-	Type types.WalletType `json:"type"`
+	// Type only returns wallets of the type, such as types.WalletTypeCAT. All wallets are returned when nil
+	Type *types.WalletType `json:"type,omitempty"`
+}
+
+// WithType returns a copy of the options that only returns wallets of the given type
+func (o GetWalletsOptions) WithType(walletType types.WalletType) *GetWalletsOptions {
+	o.Type = &walletType
+	return &o
 }
 
 // GetWalletsResponse wallet rpc -> get_wallets
@@ -143,8 +129,8 @@ type GetWalletsResponse struct {
 }
 
 // GetWallets wallet rpc -> get_wallets
-func (s *WalletService) GetWallets(opts *GetWalletsOptions) (*GetWalletsResponse, *http.Response, error) {
-	return Do(s, "get_wallets", opts, &GetWalletsResponse{})
+func (s *WalletService) GetWallets(opts *GetWalletsOptions, options ...rpcinterface.RequestOptionFunc) (*GetWalletsResponse, *http.Response, error) {
+	return Do(s, "get_wallets", opts, &GetWalletsResponse{}, options...)
 }
 
 // GetWalletBalanceOptions request options for get_wallet_balance
@@ -159,8 +145,8 @@ type GetWalletBalanceResponse struct {
 }
 
 // GetWalletBalance returns wallet balance
-func (s *WalletService) GetWalletBalance(opts *GetWalletBalanceOptions) (*GetWalletBalanceResponse, *http.Response, error) {
-	return Do(s, "get_wallet_balance", opts, &GetWalletBalanceResponse{})
+func (s *WalletService) GetWalletBalance(opts *GetWalletBalanceOptions, options ...rpcinterface.RequestOptionFunc) (*GetWalletBalanceResponse, *http.Response, error) {
+	return Do(s, "get_wallet_balance", opts, &GetWalletBalanceResponse{}, options...)
 }
 
 // GetWalletTransactionCountOptions options for get transaction count
@@ -176,8 +162,8 @@ type GetWalletTransactionCountResponse struct {
 }
 
 // GetTransactionCount returns the total count of transactions for the specific wallet ID
-func (s *WalletService) GetTransactionCount(opts *GetWalletTransactionCountOptions) (*GetWalletTransactionCountResponse, *http.Response, error) {
-	return Do(s, "get_transaction_count", opts, &GetWalletTransactionCountResponse{})
+func (s *WalletService) GetTransactionCount(opts *GetWalletTransactionCountOptions, options ...rpcinterface.RequestOptionFunc) (*GetWalletTransactionCountResponse, *http.Response, error) {
+	return Do(s, "get_transaction_count", opts, &GetWalletTransactionCountResponse{}, options...)
 }
 
 // GetWalletTransactionsOptions options for get wallet transactions
@@ -196,8 +182,8 @@ type GetWalletTransactionsResponse struct {
 }
 
 // GetTransactions wallet rpc -> get_transactions
-func (s *WalletService) GetTransactions(opts *GetWalletTransactionsOptions) (*GetWalletTransactionsResponse, *http.Response, error) {
-	return Do(s, "get_transactions", opts, &GetWalletTransactionsResponse{})
+func (s *WalletService) GetTransactions(opts *GetWalletTransactionsOptions, options ...rpcinterface.RequestOptionFunc) (*GetWalletTransactionsResponse, *http.Response, error) {
+	return Do(s, "get_transactions", opts, &GetWalletTransactionsResponse{}, options...)
 }
 
 // GetWalletTransactionOptions options for getting a single wallet transaction
@@ -214,8 +200,8 @@ type GetWalletTransactionResponse struct {
 }
 
 // GetTransaction returns a single transaction record
-func (s *WalletService) GetTransaction(opts *GetWalletTransactionOptions) (*GetWalletTransactionResponse, *http.Response, error) {
-	return Do(s, "get_transaction", opts, &GetWalletTransactionResponse{})
+func (s *WalletService) GetTransaction(opts *GetWalletTransactionOptions, options ...rpcinterface.RequestOptionFunc) (*GetWalletTransactionResponse, *http.Response, error) {
+	return Do(s, "get_transaction", opts, &GetWalletTransactionResponse{}, options...)
 }
 
 // SendTransactionOptions represents the options for send_transaction
@@ -236,8 +222,8 @@ type SendTransactionResponse struct {
 }
 
 // SendTransaction sends a transaction
-func (s *WalletService) SendTransaction(opts *SendTransactionOptions) (*SendTransactionResponse, *http.Response, error) {
-	return Do(s, "send_transaction", opts, &SendTransactionResponse{})
+func (s *WalletService) SendTransaction(opts *SendTransactionOptions, options ...rpcinterface.RequestOptionFunc) (*SendTransactionResponse, *http.Response, error) {
+	return Do(s, "send_transaction", opts, &SendTransactionResponse{}, options...)
 }
 
 // CatSpendOptions represents the options for cat_spend
@@ -256,8 +242,165 @@ type CatSpendResponse struct {
 }
 
 // CatSpend sends a transaction
-func (s *WalletService) CatSpend(opts *CatSpendOptions) (*CatSpendResponse, *http.Response, error) {
-	return Do(s, "cat_spend", opts, &CatSpendResponse{})
+func (s *WalletService) CatSpend(opts *CatSpendOptions, options ...rpcinterface.RequestOptionFunc) (*CatSpendResponse, *http.Response, error) {
+	return Do(s, "cat_spend", opts, &CatSpendResponse{}, options...)
+}
+
+// CreateNewCATWalletOptions options for create_new_wallet when issuing a new CAT
+type CreateNewCATWalletOptions struct {
+	// Amount is the number of mojos of the new CAT to issue
+	Amount uint64 `json:"amount"`
+	Fee    uint64 `json:"fee"`
+	Name   string `json:"name,omitempty"`
+
+	// Test must be true for chia to issue the CAT. Issuing CATs through the RPC is only meant for testing, and the CAT
+	// admin tool should be used otherwise
+	Test bool `json:"test"`
+}
+
+// createNewCATWalletRequest is the request body for create_new_wallet when issuing a new CAT
+type createNewCATWalletRequest struct {
+	WalletType string `json:"wallet_type"`
+	Mode       string `json:"mode"`
+	CreateNewCATWalletOptions
+}
+
+// CreateCATWalletForAssetIDOptions options for create_new_wallet when adding a wallet for an existing CAT
+type CreateCATWalletForAssetIDOptions struct {
+	AssetID types.Bytes32 `json:"asset_id"`
+	Name    string        `json:"name,omitempty"`
+}
+
+// createCATWalletForAssetIDRequest is the request body for create_new_wallet when adding a wallet for an existing CAT
+type createCATWalletForAssetIDRequest struct {
+	WalletType string `json:"wallet_type"`
+	Mode       string `json:"mode"`
+	CreateCATWalletForAssetIDOptions
+}
+
+// CreateNewCATWalletResponse response from create_new_wallet when creating a CAT wallet
+type CreateNewCATWalletResponse struct {
+	rpcinterface.Response
+	Type     mo.Option[types.WalletType] `json:"type"`
+	AssetID  mo.Option[types.Bytes32]    `json:"asset_id"`
+	WalletID mo.Option[uint32]           `json:"wallet_id"`
+
+	// Transactions are the transactions that issued the CAT. Not set when adding a wallet for an existing CAT
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// CreateNewCATWallet issues a new CAT, and creates a wallet to hold it
+func (s *WalletService) CreateNewCATWallet(opts *CreateNewCATWalletOptions, options ...rpcinterface.RequestOptionFunc) (*CreateNewCATWalletResponse, *http.Response, error) {
+	request := &createNewCATWalletRequest{
+		WalletType: "cat_wallet",
+		Mode:       "new",
+	}
+	if opts != nil {
+		request.CreateNewCATWalletOptions = *opts
+	}
+	return Do(s, "create_new_wallet", request, &CreateNewCATWalletResponse{}, options...)
+}
+
+// CreateCATWalletForAssetID creates a wallet for an existing CAT, or returns the existing wallet if there is one
+func (s *WalletService) CreateCATWalletForAssetID(opts *CreateCATWalletForAssetIDOptions, options ...rpcinterface.RequestOptionFunc) (*CreateNewCATWalletResponse, *http.Response, error) {
+	request := &createCATWalletForAssetIDRequest{
+		WalletType: "cat_wallet",
+		Mode:       "existing",
+	}
+	if opts != nil {
+		request.CreateCATWalletForAssetIDOptions = *opts
+	}
+	return Do(s, "create_new_wallet", request, &CreateNewCATWalletResponse{}, options...)
+}
+
+// CATGetAssetIDOptions options for cat_get_asset_id
+type CATGetAssetIDOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// CATGetAssetIDResponse response from cat_get_asset_id
+type CATGetAssetIDResponse struct {
+	rpcinterface.Response
+	AssetID  mo.Option[types.Bytes32] `json:"asset_id"`
+	WalletID mo.Option[uint32]        `json:"wallet_id"`
+}
+
+// CATGetAssetID returns the asset ID of the CAT in the wallet
+func (s *WalletService) CATGetAssetID(opts *CATGetAssetIDOptions, options ...rpcinterface.RequestOptionFunc) (*CATGetAssetIDResponse, *http.Response, error) {
+	return Do(s, "cat_get_asset_id", opts, &CATGetAssetIDResponse{}, options...)
+}
+
+// CATSetNameOptions options for cat_set_name
+type CATSetNameOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+	Name     string `json:"name"`
+}
+
+// CATSetNameResponse response from cat_set_name
+type CATSetNameResponse struct {
+	rpcinterface.Response
+	WalletID mo.Option[uint32] `json:"wallet_id"`
+}
+
+// CATSetName renames the CAT wallet
+func (s *WalletService) CATSetName(opts *CATSetNameOptions, options ...rpcinterface.RequestOptionFunc) (*CATSetNameResponse, *http.Response, error) {
+	return Do(s, "cat_set_name", opts, &CATSetNameResponse{}, options...)
+}
+
+// CATGetNameOptions options for cat_get_name
+type CATGetNameOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// CATGetNameResponse response from cat_get_name
+type CATGetNameResponse struct {
+	rpcinterface.Response
+	WalletID mo.Option[uint32] `json:"wallet_id"`
+	Name     mo.Option[string] `json:"name"`
+}
+
+// CATGetName returns the name of the CAT wallet
+func (s *WalletService) CATGetName(opts *CATGetNameOptions, options ...rpcinterface.RequestOptionFunc) (*CATGetNameResponse, *http.Response, error) {
+	return Do(s, "cat_get_name", opts, &CATGetNameResponse{}, options...)
+}
+
+// CATAssetIDToNameOptions options for cat_asset_id_to_name
+type CATAssetIDToNameOptions struct {
+	AssetID types.Bytes32
+}
+
+// MarshalJSON encodes the asset ID as hex without the 0x prefix, since chia compares it to the un-prefixed asset IDs
+func (o CATAssetIDToNameOptions) MarshalJSON() ([]byte, error) {
+	request := struct {
+		AssetID string `json:"asset_id"`
+	}{
+		AssetID: hex.EncodeToString(o.AssetID[:]),
+	}
+	return json.Marshal(request)
+}
+
+// CATAssetIDToNameResponse response from cat_asset_id_to_name
+// WalletID is only set if the wallet has a CAT wallet for the asset, and Name is not set if the asset is unknown
+type CATAssetIDToNameResponse struct {
+	rpcinterface.Response
+	WalletID mo.Option[uint32] `json:"wallet_id"`
+	Name     mo.Option[string] `json:"name"`
+}
+
+// CATAssetIDToName returns the name of a CAT, from the wallet if there is a CAT wallet for it, or the default CAT list
+func (s *WalletService) CATAssetIDToName(opts *CATAssetIDToNameOptions, options ...rpcinterface.RequestOptionFunc) (*CATAssetIDToNameResponse, *http.Response, error) {
+	return Do(s, "cat_asset_id_to_name", opts, &CATAssetIDToNameResponse{}, options...)
+}
+
+// GetCATListResponse response from get_cat_list
+type GetCATListResponse struct {
+	rpcinterface.Response
+	CATList mo.Option[[]types.CAT] `json:"cat_list"`
+}
+
+// GetCATList returns the well known CATs the wallet has names for
+func (s *WalletService) GetCATList(options ...rpcinterface.RequestOptionFunc) (*GetCATListResponse, *http.Response, error) {
+	return Do(s, "get_cat_list", nil, &GetCATListResponse{}, options...)
 }
 
 // MintNFTOptions represents the options for nft_get_info
@@ -286,8 +429,8 @@ type MintNFTResponse struct {
 }
 
 // MintNFT Mint a new NFT
-func (s *WalletService) MintNFT(opts *MintNFTOptions) (*MintNFTResponse, *http.Response, error) {
-	return Do(s, "nft_mint_nft", opts, &MintNFTResponse{})
+func (s *WalletService) MintNFT(opts *MintNFTOptions, options ...rpcinterface.RequestOptionFunc) (*MintNFTResponse, *http.Response, error) {
+	return Do(s, "nft_mint_nft", opts, &MintNFTResponse{}, options...)
 }
 
 // GetNFTsOptions represents the options for nft_get_nfts
@@ -305,8 +448,8 @@ type GetNFTsResponse struct {
 }
 
 // GetNFTs Show all NFTs in a given wallet
-func (s *WalletService) GetNFTs(opts *GetNFTsOptions) (*GetNFTsResponse, *http.Response, error) {
-	return Do(s, "nft_get_nfts", opts, &GetNFTsResponse{})
+func (s *WalletService) GetNFTs(opts *GetNFTsOptions, options ...rpcinterface.RequestOptionFunc) (*GetNFTsResponse, *http.Response, error) {
+	return Do(s, "nft_get_nfts", opts, &GetNFTsResponse{}, options...)
 }
 
 // TransferNFTOptions represents the options for nft_get_info
@@ -325,8 +468,8 @@ type TransferNFTResponse struct {
 }
 
 // TransferNFT Get info about an NFT
-func (s *WalletService) TransferNFT(opts *TransferNFTOptions) (*TransferNFTResponse, *http.Response, error) {
-	return Do(s, "nft_transfer_nft", opts, &TransferNFTResponse{})
+func (s *WalletService) TransferNFT(opts *TransferNFTOptions, options ...rpcinterface.RequestOptionFunc) (*TransferNFTResponse, *http.Response, error) {
+	return Do(s, "nft_transfer_nft", opts, &TransferNFTResponse{}, options...)
 }
 
 // GetNFTInfoOptions represents the options for nft_get_info
@@ -342,8 +485,8 @@ type GetNFTInfoResponse struct {
 }
 
 // GetNFTInfo Get info about an NFT
-func (s *WalletService) GetNFTInfo(opts *GetNFTInfoOptions) (*GetNFTInfoResponse, *http.Response, error) {
-	return Do(s, "nft_get_info", opts, &GetNFTInfoResponse{})
+func (s *WalletService) GetNFTInfo(opts *GetNFTInfoOptions, options ...rpcinterface.RequestOptionFunc) (*GetNFTInfoResponse, *http.Response, error) {
+	return Do(s, "nft_get_info", opts, &GetNFTInfoResponse{}, options...)
 }
 
 // NFTAddURIOptions represents the options for nft_add_uri
@@ -363,8 +506,8 @@ type NFTAddURIResponse struct {
 }
 
 // NFTAddURI Get info about an NFT
-func (s *WalletService) NFTAddURI(opts *NFTAddURIOptions) (*NFTAddURIResponse, *http.Response, error) {
-	return Do(s, "nft_add_uri", opts, &NFTAddURIResponse{})
+func (s *WalletService) NFTAddURI(opts *NFTAddURIOptions, options ...rpcinterface.RequestOptionFunc) (*NFTAddURIResponse, *http.Response, error) {
+	return Do(s, "nft_add_uri", opts, &NFTAddURIResponse{}, options...)
 }
 
 // NFTGetByDidOptions represents the options for nft_get_by_did
@@ -379,8 +522,233 @@ type NFTGetByDidResponse struct {
 }
 
 // NFTGetByDid Get wallet ID by DID
-func (s *WalletService) NFTGetByDid(opts *NFTGetByDidOptions) (*NFTGetByDidResponse, *http.Response, error) {
-	return Do(s, "nft_get_by_did", opts, &NFTGetByDidResponse{})
+func (s *WalletService) NFTGetByDid(opts *NFTGetByDidOptions, options ...rpcinterface.RequestOptionFunc) (*NFTGetByDidResponse, *http.Response, error) {
+	return Do(s, "nft_get_by_did", opts, &NFTGetByDidResponse{}, options...)
+}
+
+// CreateNewDIDWalletOptions options for create_new_wallet when creating a DID wallet
+type CreateNewDIDWalletOptions struct {
+	// Amount is the amount of mojos in the DID singleton, which must be odd
+	Amount     uint64            `json:"amount"`
+	Fee        uint64            `json:"fee"`
+	WalletName string            `json:"wallet_name,omitempty"`
+	Metadata   types.DIDMetadata `json:"metadata,omitempty"`
+
+	// BackupDIDs are did:chia: identifiers of the DIDs that can recover this DID
+	BackupDIDs           []string `json:"backup_dids"`
+	NumOfBackupIDsNeeded uint64   `json:"num_of_backup_ids_needed"`
+}
+
+// createNewDIDWalletRequest is the request body for create_new_wallet when creating a DID wallet
+type createNewDIDWalletRequest struct {
+	WalletType string `json:"wallet_type"`
+	DIDType    string `json:"did_type"`
+	CreateNewDIDWalletOptions
+}
+
+// CreateNewDIDWalletResponse response from create_new_wallet when creating a DID wallet
+type CreateNewDIDWalletResponse struct {
+	rpcinterface.Response
+	Type     mo.Option[types.WalletType] `json:"type"`
+	MyDID    mo.Option[string]           `json:"my_did"`
+	WalletID mo.Option[uint32]           `json:"wallet_id"`
+}
+
+// CreateNewDIDWallet creates a new DID, and a wallet to hold it
+func (s *WalletService) CreateNewDIDWallet(opts *CreateNewDIDWalletOptions, options ...rpcinterface.RequestOptionFunc) (*CreateNewDIDWalletResponse, *http.Response, error) {
+	request := &createNewDIDWalletRequest{
+		WalletType: "did_wallet",
+		DIDType:    "new",
+	}
+	if opts != nil {
+		request.CreateNewDIDWalletOptions = *opts
+	}
+	// chia requires backup_dids, even when empty
+	if request.BackupDIDs == nil {
+		request.BackupDIDs = []string{}
+	}
+	return Do(s, "create_new_wallet", request, &CreateNewDIDWalletResponse{}, options...)
+}
+
+// DIDGetDIDOptions options for did_get_did
+type DIDGetDIDOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// DIDGetDIDResponse response from did_get_did
+type DIDGetDIDResponse struct {
+	rpcinterface.Response
+	WalletID mo.Option[uint32]        `json:"wallet_id"`
+	MyDID    mo.Option[string]        `json:"my_did"`
+	CoinID   mo.Option[types.Bytes32] `json:"coin_id"`
+}
+
+// DIDGetDID returns the did:chia: identifier of the DID in the wallet
+func (s *WalletService) DIDGetDID(opts *DIDGetDIDOptions, options ...rpcinterface.RequestOptionFunc) (*DIDGetDIDResponse, *http.Response, error) {
+	return Do(s, "did_get_did", opts, &DIDGetDIDResponse{}, options...)
+}
+
+// DIDGetInfoOptions options for did_get_info
+type DIDGetInfoOptions struct {
+	// CoinID is a did:chia: identifier, or the hex ID of any coin in the DID singleton
+	CoinID string `json:"coin_id"`
+
+	// Latest looks up the current state of the DID, instead of the state at CoinID
+	Latest bool `json:"latest"`
+}
+
+// DIDGetInfoResponse response from did_get_info
+type DIDGetInfoResponse struct {
+	rpcinterface.Response
+	types.DIDInfo
+}
+
+// DIDGetInfo returns the on chain state of any DID, not only those in the wallet
+func (s *WalletService) DIDGetInfo(opts *DIDGetInfoOptions, options ...rpcinterface.RequestOptionFunc) (*DIDGetInfoResponse, *http.Response, error) {
+	return Do(s, "did_get_info", opts, &DIDGetInfoResponse{}, options...)
+}
+
+// DIDUpdateMetadataOptions options for did_update_metadata
+type DIDUpdateMetadataOptions struct {
+	WalletID uint32            `json:"wallet_id"`
+	Metadata types.DIDMetadata `json:"metadata"`
+	Fee      uint64            `json:"fee"`
+}
+
+// DIDUpdateMetadataResponse response from did_update_metadata
+type DIDUpdateMetadataResponse struct {
+	rpcinterface.Response
+	WalletID     mo.Option[uint32]                    `json:"wallet_id"`
+	SpendBundle  mo.Option[types.SpendBundle]         `json:"spend_bundle"`
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// DIDUpdateMetadata replaces the metadata of the DID in the wallet
+func (s *WalletService) DIDUpdateMetadata(opts *DIDUpdateMetadataOptions, options ...rpcinterface.RequestOptionFunc) (*DIDUpdateMetadataResponse, *http.Response, error) {
+	return Do(s, "did_update_metadata", opts, &DIDUpdateMetadataResponse{}, options...)
+}
+
+// DIDGetMetadataOptions options for did_get_metadata
+type DIDGetMetadataOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// DIDGetMetadataResponse response from did_get_metadata
+type DIDGetMetadataResponse struct {
+	rpcinterface.Response
+	WalletID mo.Option[uint32]            `json:"wallet_id"`
+	Metadata mo.Option[types.DIDMetadata] `json:"metadata"`
+}
+
+// DIDGetMetadata returns the metadata of the DID in the wallet
+func (s *WalletService) DIDGetMetadata(opts *DIDGetMetadataOptions, options ...rpcinterface.RequestOptionFunc) (*DIDGetMetadataResponse, *http.Response, error) {
+	return Do(s, "did_get_metadata", opts, &DIDGetMetadataResponse{}, options...)
+}
+
+// DIDTransferDIDOptions options for did_transfer_did
+type DIDTransferDIDOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+
+	// InnerAddress is the address of the new owner
+	InnerAddress string `json:"inner_address"`
+	Fee          uint64 `json:"fee"`
+
+	// WithRecoveryInfo keeps the recovery list of the DID. Defaults to true when not set
+	WithRecoveryInfo *bool `json:"with_recovery_info,omitempty"`
+}
+
+// DIDTransferDIDResponse response from did_transfer_did
+type DIDTransferDIDResponse struct {
+	rpcinterface.Response
+	TransactionID mo.Option[types.Bytes32]             `json:"transaction_id"`
+	Transaction   mo.Option[types.TransactionRecord]   `json:"transaction"`
+	Transactions  mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// DIDTransferDID transfers the DID in the wallet to another address
+func (s *WalletService) DIDTransferDID(opts *DIDTransferDIDOptions, options ...rpcinterface.RequestOptionFunc) (*DIDTransferDIDResponse, *http.Response, error) {
+	return Do(s, "did_transfer_did", opts, &DIDTransferDIDResponse{}, options...)
+}
+
+// DIDFindLostDIDOptions options for did_find_lost_did
+type DIDFindLostDIDOptions struct {
+	// CoinID is a did:chia: identifier, or the hex ID of any coin in the DID singleton
+	CoinID string `json:"coin_id"`
+
+	// The following override the values found on chain, for DIDs whose puzzle can't be recovered otherwise
+	RecoveryListHash *types.Bytes32    `json:"recovery_list_hash,omitempty"`
+	NumVerification  *uint64           `json:"num_verification,omitempty"`
+	Metadata         types.DIDMetadata `json:"metadata,omitempty"`
+}
+
+// DIDFindLostDIDResponse response from did_find_lost_did
+type DIDFindLostDIDResponse struct {
+	rpcinterface.Response
+	LatestCoinID mo.Option[types.Bytes32] `json:"latest_coin_id"`
+}
+
+// DIDFindLostDID recovers a DID that the wallet owns but isn't tracking, such as after a failed transfer
+func (s *WalletService) DIDFindLostDID(opts *DIDFindLostDIDOptions, options ...rpcinterface.RequestOptionFunc) (*DIDFindLostDIDResponse, *http.Response, error) {
+	return Do(s, "did_find_lost_did", opts, &DIDFindLostDIDResponse{}, options...)
+}
+
+// DIDGetCurrentCoinInfoOptions options for did_get_current_coin_info
+type DIDGetCurrentCoinInfoOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// DIDGetCurrentCoinInfoResponse response from did_get_current_coin_info
+type DIDGetCurrentCoinInfoResponse struct {
+	rpcinterface.Response
+	WalletID    mo.Option[uint32]        `json:"wallet_id"`
+	MyDID       mo.Option[string]        `json:"my_did"`
+	DIDParent   mo.Option[types.Bytes32] `json:"did_parent"`
+	DIDInnerpuz mo.Option[types.Bytes32] `json:"did_innerpuz"`
+	DIDAmount   mo.Option[uint64]        `json:"did_amount"`
+}
+
+// DIDGetCurrentCoinInfo returns the parent, inner puzzle hash and amount of the current coin of the DID in the wallet
+func (s *WalletService) DIDGetCurrentCoinInfo(opts *DIDGetCurrentCoinInfoOptions, options ...rpcinterface.RequestOptionFunc) (*DIDGetCurrentCoinInfoResponse, *http.Response, error) {
+	return Do(s, "did_get_current_coin_info", opts, &DIDGetCurrentCoinInfoResponse{}, options...)
+}
+
+// DIDMessageSpendOptions options for did_message_spend
+type DIDMessageSpendOptions struct {
+	WalletID            uint32
+	CoinAnnouncements   []types.Bytes
+	PuzzleAnnouncements []types.Bytes
+}
+
+// MarshalJSON encodes the announcements as hex without the 0x prefix, which is the only format chia accepts for them
+func (o DIDMessageSpendOptions) MarshalJSON() ([]byte, error) {
+	request := struct {
+		WalletID            uint32   `json:"wallet_id"`
+		CoinAnnouncements   []string `json:"coin_announcements"`
+		PuzzleAnnouncements []string `json:"puzzle_announcements"`
+	}{
+		WalletID:            o.WalletID,
+		CoinAnnouncements:   []string{},
+		PuzzleAnnouncements: []string{},
+	}
+	for _, announcement := range o.CoinAnnouncements {
+		request.CoinAnnouncements = append(request.CoinAnnouncements, hex.EncodeToString(announcement))
+	}
+	for _, announcement := range o.PuzzleAnnouncements {
+		request.PuzzleAnnouncements = append(request.PuzzleAnnouncements, hex.EncodeToString(announcement))
+	}
+	return json.Marshal(request)
+}
+
+// DIDMessageSpendResponse response from did_message_spend
+type DIDMessageSpendResponse struct {
+	rpcinterface.Response
+	SpendBundle  mo.Option[types.SpendBundle]         `json:"spend_bundle"`
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// DIDMessageSpend creates a spend of the DID that makes the coin and puzzle announcements
+func (s *WalletService) DIDMessageSpend(opts *DIDMessageSpendOptions, options ...rpcinterface.RequestOptionFunc) (*DIDMessageSpendResponse, *http.Response, error) {
+	return Do(s, "did_message_spend", opts, &DIDMessageSpendResponse{}, options...)
 }
 
 // GetSpendableCoinsOptions Options for get_spendable_coins
@@ -400,8 +768,8 @@ type GetSpendableCoinsResponse struct {
 }
 
 // GetSpendableCoins returns information about the coins in the wallet
-func (s *WalletService) GetSpendableCoins(opts *GetSpendableCoinsOptions) (*GetSpendableCoinsResponse, *http.Response, error) {
-	return Do(s, "get_spendable_coins", opts, &GetSpendableCoinsResponse{})
+func (s *WalletService) GetSpendableCoins(opts *GetSpendableCoinsOptions, options ...rpcinterface.RequestOptionFunc) (*GetSpendableCoinsResponse, *http.Response, error) {
+	return Do(s, "get_spendable_coins", opts, &GetSpendableCoinsResponse{}, options...)
 }
 
 // CreateSignedTransactionOptions Options for create_signed_transaction endpoint
@@ -424,8 +792,8 @@ type CreateSignedTransactionResponse struct {
 }
 
 // CreateSignedTransaction generates a signed transaction based on the specified options
-func (s *WalletService) CreateSignedTransaction(opts *CreateSignedTransactionOptions) (*CreateSignedTransactionResponse, *http.Response, error) {
-	return Do(s, "create_signed_transaction", opts, &CreateSignedTransactionResponse{})
+func (s *WalletService) CreateSignedTransaction(opts *CreateSignedTransactionOptions, options ...rpcinterface.RequestOptionFunc) (*CreateSignedTransactionResponse, *http.Response, error) {
+	return Do(s, "create_signed_transaction", opts, &CreateSignedTransactionResponse{}, options...)
 }
 
 // SendTransactionMultiResponse Response from send_transaction_multi
@@ -437,8 +805,8 @@ type SendTransactionMultiResponse struct {
 
 // SendTransactionMulti allows sending a more detailed transaction with multiple inputs/outputs.
 // Options are the same as create signed transaction since this is ultimately just a wrapper around that in Chia
-func (s *WalletService) SendTransactionMulti(opts *CreateSignedTransactionOptions) (*SendTransactionMultiResponse, *http.Response, error) {
-	return Do(s, "send_transaction_multi", opts, &SendTransactionMultiResponse{})
+func (s *WalletService) SendTransactionMulti(opts *CreateSignedTransactionOptions, options ...rpcinterface.RequestOptionFunc) (*SendTransactionMultiResponse, *http.Response, error) {
+	return Do(s, "send_transaction_multi", opts, &SendTransactionMultiResponse{}, options...)
 }
 
 // GetCoinRecordsOptions options for get_coin_records endpoint
@@ -453,8 +821,8 @@ type GetCoinRecordsResponse struct {
 }
 
 // GetCoinRecords returns coin records for the specified wallet
-func (s *WalletService) GetCoinRecords(opts *GetCoinRecordsOptions) (*GetCoinRecordsResponse, *http.Response, error) {
-	return Do(s, "get_coin_records", opts, &GetCoinRecordsResponse{})
+func (s *WalletService) GetCoinRecords(opts *GetCoinRecordsOptions, options ...rpcinterface.RequestOptionFunc) (*GetCoinRecordsResponse, *http.Response, error) {
+	return Do(s, "get_coin_records", opts, &GetCoinRecordsResponse{}, options...)
 }
 
 // SplitCoinsOptions options for split_coins endpoint
@@ -474,6 +842,236 @@ type SplitCoinsResponse struct {
 }
 
 // SplitCoins splits a coin into multiple smaller coins
-func (s *WalletService) SplitCoins(opts *SplitCoinsOptions) (*SplitCoinsResponse, *http.Response, error) {
-	return Do(s, "split_coins", opts, &SplitCoinsResponse{})
+func (s *WalletService) SplitCoins(opts *SplitCoinsOptions, options ...rpcinterface.RequestOptionFunc) (*SplitCoinsResponse, *http.Response, error) {
+	return Do(s, "split_coins", opts, &SplitCoinsResponse{}, options...)
+}
+
+// CreateOfferForIDsOptions options for create_offer_for_ids
+type CreateOfferForIDsOptions struct {
+	// Offer maps wallet IDs, or asset IDs for NFTs, to amounts. Negative amounts are offered, positive amounts are requested
+	Offer map[string]int64 `json:"offer"`
+
+	// DriverDict describes assets the wallet doesn't have a wallet for yet, keyed by asset ID
+	DriverDict   map[string]types.PuzzleInfo `json:"driver_dict,omitempty"`
+	Fee          uint64                      `json:"fee"`
+	ValidateOnly bool                        `json:"validate_only"`
+}
+
+// CreateOfferForIDsResponse response from create_offer_for_ids
+type CreateOfferForIDsResponse struct {
+	rpcinterface.Response
+	Offer        mo.Option[string]                    `json:"offer"`
+	TradeRecord  mo.Option[types.TradeRecord]         `json:"trade_record"`
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// CreateOfferForIDs creates an offer. The offer is only saved in the wallet if ValidateOnly is false
+func (s *WalletService) CreateOfferForIDs(opts *CreateOfferForIDsOptions, options ...rpcinterface.RequestOptionFunc) (*CreateOfferForIDsResponse, *http.Response, error) {
+	return Do(s, "create_offer_for_ids", opts, &CreateOfferForIDsResponse{}, options...)
+}
+
+// GetOfferSummaryOptions options for get_offer_summary
+type GetOfferSummaryOptions struct {
+	Offer string `json:"offer"`
+
+	// Advanced summarizes the offer with all drivers, instead of just the assets the wallet recognizes
+	Advanced bool `json:"advanced"`
+}
+
+// GetOfferSummaryResponse response from get_offer_summary
+type GetOfferSummaryResponse struct {
+	rpcinterface.Response
+	Summary mo.Option[types.OfferSummary] `json:"summary"`
+	ID      mo.Option[types.Bytes32]      `json:"id"`
+}
+
+// GetOfferSummary returns what an offer gives and asks for
+func (s *WalletService) GetOfferSummary(opts *GetOfferSummaryOptions, options ...rpcinterface.RequestOptionFunc) (*GetOfferSummaryResponse, *http.Response, error) {
+	return Do(s, "get_offer_summary", opts, &GetOfferSummaryResponse{}, options...)
+}
+
+// CheckOfferValidityOptions options for check_offer_validity
+type CheckOfferValidityOptions struct {
+	Offer string `json:"offer"`
+}
+
+// CheckOfferValidityResponse response from check_offer_validity
+type CheckOfferValidityResponse struct {
+	rpcinterface.Response
+	Valid mo.Option[bool]          `json:"valid"`
+	ID    mo.Option[types.Bytes32] `json:"id"`
+}
+
+// CheckOfferValidity checks that the coins in an offer haven't been spent
+func (s *WalletService) CheckOfferValidity(opts *CheckOfferValidityOptions, options ...rpcinterface.RequestOptionFunc) (*CheckOfferValidityResponse, *http.Response, error) {
+	return Do(s, "check_offer_validity", opts, &CheckOfferValidityResponse{}, options...)
+}
+
+// TakeOfferOptions options for take_offer
+type TakeOfferOptions struct {
+	Offer string `json:"offer"`
+	Fee   uint64 `json:"fee"`
+}
+
+// TakeOfferResponse response from take_offer
+type TakeOfferResponse struct {
+	rpcinterface.Response
+	Offer        mo.Option[string]                    `json:"offer"`
+	TradeRecord  mo.Option[types.TradeRecord]         `json:"trade_record"`
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// TakeOffer accepts an offer
+func (s *WalletService) TakeOffer(opts *TakeOfferOptions, options ...rpcinterface.RequestOptionFunc) (*TakeOfferResponse, *http.Response, error) {
+	return Do(s, "take_offer", opts, &TakeOfferResponse{}, options...)
+}
+
+// GetOfferOptions options for get_offer
+type GetOfferOptions struct {
+	TradeID types.Bytes32 `json:"trade_id"`
+
+	// FileContents includes the offer itself in the response
+	FileContents bool `json:"file_contents"`
+}
+
+// GetOfferResponse response from get_offer
+type GetOfferResponse struct {
+	rpcinterface.Response
+	TradeRecord mo.Option[types.TradeRecord] `json:"trade_record"`
+	Offer       mo.Option[string]            `json:"offer"`
+}
+
+// GetOffer returns the trade record for an offer the wallet created or took
+func (s *WalletService) GetOffer(opts *GetOfferOptions, options ...rpcinterface.RequestOptionFunc) (*GetOfferResponse, *http.Response, error) {
+	return Do(s, "get_offer", opts, &GetOfferResponse{}, options...)
+}
+
+// GetAllOffersOptions options for get_all_offers
+type GetAllOffersOptions struct {
+	Start              *int   `json:"start,omitempty"`
+	End                *int   `json:"end,omitempty"`
+	ExcludeMyOffers    bool   `json:"exclude_my_offers"`
+	ExcludeTakenOffers bool   `json:"exclude_taken_offers"`
+	IncludeCompleted   bool   `json:"include_completed"`
+	SortKey            string `json:"sort_key,omitempty"`
+	Reverse            bool   `json:"reverse"`
+	FileContents       bool   `json:"file_contents"`
+}
+
+// GetAllOffersResponse response from get_all_offers
+type GetAllOffersResponse struct {
+	rpcinterface.Response
+	TradeRecords mo.Option[[]types.TradeRecord] `json:"trade_records"`
+
+	// Offers is only set when FileContents is true, in the same order as TradeRecords
+	Offers mo.Option[[]string] `json:"offers"`
+}
+
+// GetAllOffers returns the offers the wallet created or took
+func (s *WalletService) GetAllOffers(opts *GetAllOffersOptions, options ...rpcinterface.RequestOptionFunc) (*GetAllOffersResponse, *http.Response, error) {
+	return Do(s, "get_all_offers", opts, &GetAllOffersResponse{}, options...)
+}
+
+// GetOffersCountResponse response from get_offers_count
+type GetOffersCountResponse struct {
+	rpcinterface.Response
+	Total            mo.Option[int] `json:"total"`
+	MyOffersCount    mo.Option[int] `json:"my_offers_count"`
+	TakenOffersCount mo.Option[int] `json:"taken_offers_count"`
+}
+
+// GetOffersCount returns the number of offers the wallet created and took
+func (s *WalletService) GetOffersCount(options ...rpcinterface.RequestOptionFunc) (*GetOffersCountResponse, *http.Response, error) {
+	return Do(s, "get_offers_count", nil, &GetOffersCountResponse{}, options...)
+}
+
+// CancelOfferOptions options for cancel_offer
+type CancelOfferOptions struct {
+	TradeID types.Bytes32 `json:"trade_id"`
+
+	// Secure cancels the offer on chain by spending its coins. Otherwise, the offer is only removed from the wallet,
+	// and can still be taken by anyone who has a copy of it
+	Secure bool   `json:"secure"`
+	Fee    uint64 `json:"fee"`
+}
+
+// CancelOfferResponse response from cancel_offer
+type CancelOfferResponse struct {
+	rpcinterface.Response
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// CancelOffer cancels an offer the wallet created
+func (s *WalletService) CancelOffer(opts *CancelOfferOptions, options ...rpcinterface.RequestOptionFunc) (*CancelOfferResponse, *http.Response, error) {
+	return Do(s, "cancel_offer", opts, &CancelOfferResponse{}, options...)
+}
+
+// CancelOffersOptions options for cancel_offers
+type CancelOffersOptions struct {
+	// Secure cancels the offers on chain by spending their coins
+	Secure   bool   `json:"secure"`
+	BatchFee uint64 `json:"batch_fee"`
+
+	// BatchSize is the number of offers cancelled per transaction. Defaults to 5
+	BatchSize int `json:"batch_size,omitempty"`
+
+	// CancelAll cancels every pending offer. Otherwise, only offers of AssetID are cancelled
+	CancelAll bool `json:"cancel_all"`
+
+	// AssetID is the asset of the offers to cancel. Defaults to xch
+	AssetID string `json:"asset_id,omitempty"`
+}
+
+// CancelOffersResponse response from cancel_offers
+type CancelOffersResponse struct {
+	rpcinterface.Response
+	Transactions mo.Option[[]types.TransactionRecord] `json:"transactions"`
+}
+
+// CancelOffers cancels pending offers in batches
+func (s *WalletService) CancelOffers(opts *CancelOffersOptions, options ...rpcinterface.RequestOptionFunc) (*CancelOffersResponse, *http.Response, error) {
+	return Do(s, "cancel_offers", opts, &CancelOffersResponse{}, options...)
+}
+
+const (
+	// DefaultTransactionsPageSize is the number of transactions requested per get_transactions call when iterating
+	DefaultTransactionsPageSize = 50
+
+	// MaxTransactionsPageSize is the most transactions requested per get_transactions call when iterating
+	MaxTransactionsPageSize = 500
+)
+
+// IterateTransactionsOptions options for IterateTransactions
+type IterateTransactionsOptions struct {
+	WalletID  uint32
+	ToAddress string
+	// PageSize is the number of transactions to request per call. Capped at MaxTransactionsPageSize
+	PageSize int
+}
+
+// IterateTransactions returns an iterator over all transactions for the wallet, calling get_transactions one page at a time
+func (s *WalletService) IterateTransactions(opts *IterateTransactionsOptions, options ...rpcinterface.RequestOptionFunc) *Iterator[types.TransactionRecord] {
+	if opts == nil {
+		return errorIterator[types.TransactionRecord](errors.New("IterateTransactions requires options with the wallet ID"))
+	}
+	pageSize := clampPageSize(opts.PageSize, DefaultTransactionsPageSize, MaxTransactionsPageSize)
+	cursor := 0
+
+	return NewIterator(func(_ context.Context, options ...rpcinterface.RequestOptionFunc) ([]types.TransactionRecord, bool, error) {
+		start := cursor
+		end := cursor + pageSize
+		transactions, _, err := s.GetTransactions(&GetWalletTransactionsOptions{
+			WalletID:  opts.WalletID,
+			Start:     &start,
+			End:       &end,
+			ToAddress: opts.ToAddress,
+		}, options...)
+		if err != nil {
+			return nil, false, err
+		}
+
+		records := transactions.Transactions.OrEmpty()
+		cursor = end
+		return records, len(records) < pageSize, nil
+	}, options...)
 }
@@ -2,10 +2,12 @@ package rpc
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"testing"
 
 	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
@@ -93,3 +95,220 @@ func TestNFTGetByDid(t *testing.T) {
 	require.NotNil(t, resp)
 	require.Equal(t, want, *r)
 }
+
+func TestGetOffer(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/get_offer", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, fixture("wallet/get_offer.json"))
+	})
+
+	tradeID := getBytes32FromHexString(t, "0x7d2b1a5f7e1bce5b5a5d9a6ab6a1cf5f2a2e2b5b7cf1b5b8d3e9a7b1c2d3e4f5")
+	response, _, err := client.WalletService.GetOffer(&GetOfferOptions{TradeID: tradeID, FileContents: true})
+	require.NoError(t, err)
+	require.True(t, response.Offer.IsPresent())
+
+	record := response.TradeRecord.MustGet()
+	require.Equal(t, tradeID, record.TradeID)
+	require.Equal(t, types.TradeStatusPendingAccept, record.Status)
+	require.True(t, record.Status.IsPending())
+	require.True(t, record.IsMyOffer)
+	require.False(t, record.AcceptedAtTime.IsPresent())
+	require.Equal(t, int64(1700000000), record.CreatedAtTime.Unix())
+	require.Len(t, record.CoinsOfInterest, 1)
+	require.Equal(t, uint64(1000), record.Pending["xch"])
+	require.Equal(t, uint64(1700086400), record.ValidTimes.MustGet().MaxTime.MustGet())
+
+	assetID := "a628c1c2c6fcb74d53746157e438e108eab5c0bb3e5c80ff9b1910b3e4832913"
+	summary := record.Summary.MustGet()
+	require.Equal(t, map[string]uint64{"xch": 1000}, summary.Offered)
+	require.Equal(t, map[string]uint64{assetID: 2000}, summary.Requested)
+	require.Equal(t, "CAT", summary.Infos[assetID].Type())
+}
+
+func TestCreateOfferForIDs(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/create_offer_for_ids", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"offer": {"1": -1000, "2": 2000}, "fee": 10, "validate_only": true}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, fixture("wallet/get_offer.json"))
+	})
+
+	response, _, err := client.WalletService.CreateOfferForIDs(&CreateOfferForIDsOptions{
+		Offer:        map[string]int64{"1": -1000, "2": 2000},
+		Fee:          10,
+		ValidateOnly: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.TradeStatusPendingAccept, response.TradeRecord.MustGet().Status)
+}
+
+func TestCreateNewDIDWallet(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/create_new_wallet", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"wallet_type": "did_wallet", "did_type": "new", "amount": 1, "fee": 0, "metadata": {"name": "test"}, "backup_dids": [], "num_of_backup_ids_needed": 0}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "type": 8, "my_did": "did:chia:1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqdatgqatz0n5", "wallet_id": 3}`)
+	})
+
+	response, _, err := client.WalletService.CreateNewDIDWallet(&CreateNewDIDWalletOptions{
+		Amount:   1,
+		Metadata: types.DIDMetadata{"name": "test"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.WalletTypeDID, response.Type.MustGet())
+	require.Equal(t, uint32(3), response.WalletID.MustGet())
+}
+
+func TestDIDGetInfo(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/did_get_info", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{
+			"success": true,
+			"did_id": "did:chia:1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqdatgqatz0n5",
+			"latest_coin": "3ca34e2f816a78a87ee0f694f25843d0e0079038bde0e498cf9393b828845ace",
+			"p2_address": "xch1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqm6ks6e8mvy",
+			"public_key": "a1c1f3a3c5c7b2c88fc8a2f8c84f15e3a8eb3e4f5d0a6a0e1b8c8c6a5d2f3e1c4b7d9a8e6f0c2b1a3d4e5f6a7b8c9d0e",
+			"recovery_list_hash": "4bf5122f344554c53bde2ebb8cd2b7e3d1600ad631c385a5d7cce23c7785459a",
+			"num_verification": 0,
+			"metadata": {"name": "test"},
+			"launcher_id": "00000000000000000000000000000000000000000000000000000000000dead0",
+			"full_puzzle": "ff01ff8080",
+			"hints": ["00000000000000000000000000000000000000000000000000000000000dead0"]
+		}`)
+	})
+
+	response, _, err := client.WalletService.DIDGetInfo(&DIDGetInfoOptions{
+		CoinID: "did:chia:1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqdatgqatz0n5",
+		Latest: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, getBytes32FromHexString(t, "0x00000000000000000000000000000000000000000000000000000000000dead0"), response.LauncherID.MustGet())
+	require.Equal(t, types.DIDMetadata{"name": "test"}, response.Metadata.MustGet())
+	require.Equal(t, uint64(0), response.NumVerification.MustGet())
+	require.Len(t, response.Hints.MustGet(), 1)
+}
+
+func TestDIDMessageSpend(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/did_message_spend", func(w http.ResponseWriter, r *http.Request) {
+		// chia only accepts the announcements without the 0x prefix
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"wallet_id": 2, "coin_announcements": ["cafe"], "puzzle_announcements": []}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "transactions": []}`)
+	})
+
+	_, _, err := client.WalletService.DIDMessageSpend(&DIDMessageSpendOptions{
+		WalletID:          2,
+		CoinAnnouncements: []types.Bytes{{0xca, 0xfe}},
+	})
+	require.NoError(t, err)
+}
+
+func TestGetWalletsByType(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	var requests []string
+	mux.HandleFunc("/get_wallets", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests = append(requests, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "fingerprint": 123, "wallets": [{"id": 2, "name": "CAT a628c1c2c6fcb74d...", "type": 6, "data": "a628c1c2c6fcb74d53746157e438e108eab5c0bb3e5c80ff9b1910b3e4832913"}]}`)
+	})
+
+	// No filter returns every wallet, including the standard wallet
+	_, _, err := client.WalletService.GetWallets(&GetWalletsOptions{})
+	require.NoError(t, err)
+
+	response, _, err := client.WalletService.GetWallets(GetWalletsOptions{}.WithType(types.WalletTypeCAT))
+	require.NoError(t, err)
+	require.Equal(t, types.WalletTypeCAT, response.Wallets.MustGet()[0].Type)
+
+	require.Len(t, requests, 2)
+	require.JSONEq(t, `{}`, requests[0])
+	require.JSONEq(t, `{"type": 6}`, requests[1])
+}
+
+func TestCreateCATWalletForAssetID(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	assetID := "a628c1c2c6fcb74d53746157e438e108eab5c0bb3e5c80ff9b1910b3e4832913"
+	mux.HandleFunc("/create_new_wallet", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, fmt.Sprintf(`{"wallet_type": "cat_wallet", "mode": "existing", "asset_id": "0x%s", "name": "Spacebucks"}`, assetID), string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"success": true, "type": 6, "asset_id": "0x%s", "wallet_id": 2}`, assetID)
+	})
+
+	response, _, err := client.WalletService.CreateCATWalletForAssetID(&CreateCATWalletForAssetIDOptions{
+		AssetID: getBytes32FromHexString(t, assetID),
+		Name:    "Spacebucks",
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.WalletTypeCAT, response.Type.MustGet())
+	require.Equal(t, getBytes32FromHexString(t, assetID), response.AssetID.MustGet())
+	require.Equal(t, uint32(2), response.WalletID.MustGet())
+	require.False(t, response.Transactions.IsPresent())
+}
+
+func TestCATAssetIDToName(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	assetID := "a628c1c2c6fcb74d53746157e438e108eab5c0bb3e5c80ff9b1910b3e4832913"
+	mux.HandleFunc("/cat_asset_id_to_name", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, fmt.Sprintf(`{"asset_id": "%s"}`, assetID), string(body))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "wallet_id": 2, "name": "Spacebucks"}`)
+	})
+
+	response, _, err := client.WalletService.CATAssetIDToName(&CATAssetIDToNameOptions{
+		AssetID: getBytes32FromHexString(t, "0x"+assetID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), response.WalletID.MustGet())
+	require.Equal(t, "Spacebucks", response.Name.MustGet())
+}
+
+func TestCATAssetIDToNameUnknown(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/cat_asset_id_to_name", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"success": true, "wallet_id": null, "name": null}`)
+	})
+
+	response, _, err := client.WalletService.CATAssetIDToName(&CATAssetIDToNameOptions{})
+	require.NoError(t, err)
+	require.False(t, response.WalletID.IsPresent())
+	require.False(t, response.Name.IsPresent())
+}
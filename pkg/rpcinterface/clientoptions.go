@@ -9,3 +9,6 @@ type ClientOptionFunc func(client Client) error
 
 // ConfigOptionFunc used to specify how to load configuration for the RPC client
 type ConfigOptionFunc func() (*config.ChiaConfig, error)
+
+// RequestOptionFunc can be passed to all RPC methods to customize the individual request
+type RequestOptionFunc func(req *Request) error
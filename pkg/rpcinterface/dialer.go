@@ -0,0 +1,10 @@
+package rpcinterface
+
+import (
+	"context"
+	"net"
+)
+
+// DialContextFunc opens the connection used to reach a chia service, in the same form as net.Dialer.DialContext
+// Can be used to connect through unix sockets, SSH tunnels or in-memory connections
+type DialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
@@ -1,4 +1,52 @@
 package rpcinterface
 
+import "strings"
+
 // Endpoint represents and RPC Method
 type Endpoint string
+
+// readOnlyEndpoints are read-only endpoints that don't follow the prefix conventions checked in IsReadOnly
+var readOnlyEndpoints = map[Endpoint]bool{
+	"healthz":              true,
+	"subscriptions":        true,
+	"running_services":     true,
+	"keys":                 true,
+	"nft_count_nfts":       true,
+	"cat_asset_id_to_name": true,
+}
+
+// mutatingEndpoints are endpoints that look read-only by name, but can change state on the server
+var mutatingEndpoints = map[Endpoint]bool{
+	// Derives a new address when new_address is true
+	"get_next_address": true,
+}
+
+// walletTypePrefixes are prefixes the wallet uses to namespace endpoints for a particular wallet type
+var walletTypePrefixes = []string{"cat_", "did_", "nft_", "dl_", "pw_", "vc_", "dao_", "crcat_"}
+
+// IsReadOnly returns true if the endpoint only reads data and can safely be sent more than once
+// Anything not known to be read-only is treated as mutating
+func (e Endpoint) IsReadOnly() bool {
+	if mutatingEndpoints[e] {
+		return false
+	}
+	if readOnlyEndpoints[e] {
+		return true
+	}
+
+	name := string(e)
+	for _, prefix := range walletTypePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			name = strings.TrimPrefix(name, prefix)
+			break
+		}
+	}
+
+	for _, prefix := range []string{"get_", "is_", "check_"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
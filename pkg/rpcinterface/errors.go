@@ -0,0 +1,104 @@
+package rpcinterface
+
+import (
+	"regexp"
+)
+
+// rpcError is a sentinel for a category of RPC failure, identified by the error message chia returns
+type rpcError struct {
+	description string
+	pattern     *regexp.Regexp
+}
+
+// Error satisfies the error interface
+func (e *rpcError) Error() string {
+	return e.description
+}
+
+func (e *rpcError) matches(message string) bool {
+	return e.pattern.MatchString(message)
+}
+
+func newRPCError(description string, pattern string) *rpcError {
+	return &rpcError{
+		description: description,
+		pattern:     regexp.MustCompile(pattern),
+	}
+}
+
+// Sentinel errors for common chia RPC failures. Use with errors.Is on errors returned by the RPC client
+var (
+	// ErrWalletNotSynced the wallet must be synced before the request can be processed
+	ErrWalletNotSynced error = newRPCError("wallet is not synced", `(?i)wallet needs to be fully synced`)
+
+	// ErrCoinNotFound the requested coin or coin record does not exist
+	ErrCoinNotFound error = newRPCError("coin not found", `(?i)\bcoin\b[^.]*\bnot found`)
+
+	// ErrBlockNotFound the requested block, block record, or height does not exist
+	ErrBlockNotFound error = newRPCError("block not found", `(?i)(\bblock\b[^.]*\bnot found|height not in blockchain)`)
+
+	// ErrTransactionNotFound the requested transaction does not exist
+	ErrTransactionNotFound error = newRPCError("transaction not found", `(?i)\btransaction\b[^.]*\bnot found`)
+
+	// ErrWalletNotFound the requested wallet ID does not exist
+	ErrWalletNotFound error = newRPCError("wallet not found", `(?i)\bwallet(_id| id)\b[^.]*\b(not found|does not exist)`)
+
+	// ErrInsufficientFunds the wallet does not have enough spendable balance for the request
+	ErrInsufficientFunds error = newRPCError("insufficient funds", `(?i)(insufficient|can't (send|select amount) (more|higher) than)`)
+
+	// ErrNoKeysLoaded the wallet has no key logged in
+	ErrNoKeysLoaded error = newRPCError("no keys loaded", `(?i)no keys? (loaded|logged in)`)
+)
+
+// consensusErrPattern finds the consensus error codes that chia reports for a failed spend, either as Err.DOUBLE_SPEND
+// or as "error DOUBLE_SPEND" in the push_tx failure message
+var consensusErrPattern = regexp.MustCompile(`(?:\berror:? |\bErr\.)([A-Z][A-Z0-9]*(?:_[A-Z0-9]+)*)\b`)
+
+// ConsensusErr is a chia consensus error code (chia.util.errors.Err) such as DOUBLE_SPEND
+// Errors returned by push_tx and similar mempool calls match these with errors.Is
+// Codes that aren't in the catalogue below can be matched with ConsensusErr("SOME_CODE")
+type ConsensusErr string
+
+// Error satisfies the error interface
+func (e ConsensusErr) Error() string {
+	return string(e)
+}
+
+// Common chia consensus error codes, as reported by push_tx when a spend bundle can't be included in the mempool
+const (
+	ErrDoubleSpend                          ConsensusErr = "DOUBLE_SPEND"
+	ErrUnknownUnspent                       ConsensusErr = "UNKNOWN_UNSPENT"
+	ErrMempoolConflict                      ConsensusErr = "MEMPOOL_CONFLICT"
+	ErrMempoolNotInitialized                ConsensusErr = "MEMPOOL_NOT_INITIALIZED"
+	ErrInvalidFeeLowFee                     ConsensusErr = "INVALID_FEE_LOW_FEE"
+	ErrInvalidFeeTooCloseToZero             ConsensusErr = "INVALID_FEE_TOO_CLOSE_TO_ZERO"
+	ErrReserveFeeConditionFailed            ConsensusErr = "RESERVE_FEE_CONDITION_FAILED"
+	ErrBadAggregateSignature                ConsensusErr = "BAD_AGGREGATE_SIGNATURE"
+	ErrInvalidSpendBundle                   ConsensusErr = "INVALID_SPEND_BUNDLE"
+	ErrInvalidCondition                     ConsensusErr = "INVALID_CONDITION"
+	ErrWrongPuzzleHash                      ConsensusErr = "WRONG_PUZZLE_HASH"
+	ErrMintingCoin                          ConsensusErr = "MINTING_COIN"
+	ErrDuplicateOutput                      ConsensusErr = "DUPLICATE_OUTPUT"
+	ErrCoinAmountExceedsMaximum             ConsensusErr = "COIN_AMOUNT_EXCEEDS_MAXIMUM"
+	ErrCoinAmountNegative                   ConsensusErr = "COIN_AMOUNT_NEGATIVE"
+	ErrBlockCostExceedsMax                  ConsensusErr = "BLOCK_COST_EXCEEDS_MAX"
+	ErrGeneratorRuntimeError                ConsensusErr = "GENERATOR_RUNTIME_ERROR"
+	ErrAssertMyCoinIDFailed                 ConsensusErr = "ASSERT_MY_COIN_ID_FAILED"
+	ErrAssertMyParentIDFailed               ConsensusErr = "ASSERT_MY_PARENT_ID_FAILED"
+	ErrAssertMyPuzzleHashFailed             ConsensusErr = "ASSERT_MY_PUZZLEHASH_FAILED"
+	ErrAssertMyAmountFailed                 ConsensusErr = "ASSERT_MY_AMOUNT_FAILED"
+	ErrAssertAnnounceConsumedFailed         ConsensusErr = "ASSERT_ANNOUNCE_CONSUMED_FAILED"
+	ErrAssertHeightAbsoluteFailed           ConsensusErr = "ASSERT_HEIGHT_ABSOLUTE_FAILED"
+	ErrAssertHeightRelativeFailed           ConsensusErr = "ASSERT_HEIGHT_RELATIVE_FAILED"
+	ErrAssertSecondsAbsoluteFailed          ConsensusErr = "ASSERT_SECONDS_ABSOLUTE_FAILED"
+	ErrAssertSecondsRelativeFailed          ConsensusErr = "ASSERT_SECONDS_RELATIVE_FAILED"
+	ErrAssertBeforeHeightAbsoluteFailed     ConsensusErr = "ASSERT_BEFORE_HEIGHT_ABSOLUTE_FAILED"
+	ErrAssertBeforeHeightRelativeFailed     ConsensusErr = "ASSERT_BEFORE_HEIGHT_RELATIVE_FAILED"
+	ErrAssertBeforeSecondsAbsoluteFailed    ConsensusErr = "ASSERT_BEFORE_SECONDS_ABSOLUTE_FAILED"
+	ErrAssertBeforeSecondsRelativeFailed    ConsensusErr = "ASSERT_BEFORE_SECONDS_RELATIVE_FAILED"
+	ErrAssertConcurrentSpendFailed          ConsensusErr = "ASSERT_CONCURRENT_SPEND_FAILED"
+	ErrAssertConcurrentPuzzleFailed         ConsensusErr = "ASSERT_CONCURRENT_PUZZLE_FAILED"
+	ErrAssertEphemeralFailed                ConsensusErr = "ASSERT_EPHEMERAL_FAILED"
+	ErrImpossibleHeightAbsoluteConstraints  ConsensusErr = "IMPOSSIBLE_HEIGHT_ABSOLUTE_CONSTRAINTS"
+	ErrImpossibleSecondsAbsoluteConstraints ConsensusErr = "IMPOSSIBLE_SECONDS_ABSOLUTE_CONSTRAINTS"
+)
@@ -0,0 +1,35 @@
+package rpcinterface
+
+import "net/http"
+
+// Invoker sends the request and decodes the response into v
+type Invoker func(req *Request, v IResponse) (*http.Response, error)
+
+// Interceptor wraps every RPC call made by a client
+// The interceptor receives the request (service, endpoint, and payload in req.Data) before it is sent and must call
+// next to continue the chain. Once next returns, v holds the decoded response
+// Headers can be injected through req.Request for the HTTP clients. req.Request is nil for the websocket client
+// In websocket async mode, v is not populated since responses are delivered to the websocket handlers instead
+type Interceptor func(req *Request, v IResponse, next Invoker) (*http.Response, error)
+
+// InterceptorClient is implemented by clients that support interceptors
+// This is separate from Client so that other Client implementations aren't required to support interceptors
+type InterceptorClient interface {
+	// AddInterceptor adds an interceptor that wraps every request made with Do
+	// Interceptors are called in the order they are added
+	AddInterceptor(interceptor Interceptor)
+}
+
+// ChainInterceptors returns an Invoker that calls the interceptors in the order provided before calling final
+// The first interceptor is the outermost, so it sees the request first and the response last
+func ChainInterceptors(interceptors []Interceptor, final Invoker) Invoker {
+	invoker := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := invoker
+		invoker = func(req *Request, v IResponse) (*http.Response, error) {
+			return interceptor(req, v, next)
+		}
+	}
+	return invoker
+}
@@ -1,6 +1,9 @@
 package rpcinterface
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
 // Request is a wrapped http.Request that indicates the service we're making the RPC call to
 type Request struct {
@@ -8,4 +11,34 @@ type Request struct {
 	Endpoint Endpoint
 	Data     interface{}
 	Request  *http.Request
+
+	// Async is set by the websocket client when the request is sent in async mode, where the response is delivered to
+	// the websocket handlers instead of being decoded into the response passed to Do
+	Async bool
+
+	// ctx is the context for the request. Accessed via Context() and WithContext() the same as http.Request
+	ctx context.Context
+}
+
+// Context returns the request's context. The returned context is always non-nil; it defaults to the background context
+func (r *Request) Context() context.Context {
+	if r.ctx != nil {
+		return r.ctx
+	}
+	return context.Background()
+}
+
+// WithContext returns a shallow copy of r with its context changed to ctx
+// If the request wraps an http.Request, the http.Request is also updated to use ctx
+func (r *Request) WithContext(ctx context.Context) *Request {
+	if ctx == nil {
+		panic("nil context")
+	}
+	r2 := new(Request)
+	*r2 = *r
+	r2.ctx = ctx
+	if r.Request != nil {
+		r2.Request = r.Request.WithContext(ctx)
+	}
+	return r2
 }
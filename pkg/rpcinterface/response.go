@@ -27,7 +27,19 @@ func (r *Response) GetRPCError() string {
 }
 
 // ChiaRPCError is the specific error returned when the RPC request succeeds, but returns success: false and an error
+// Use errors.Is with the Err* sentinels in this package, or with a ConsensusErr, to check for specific failures
+// Only returned in the HTTP connection modes. The websocket client doesn't return a response that can be checked
 type ChiaRPCError struct {
+	// Service is the service the request was sent to
+	Service ServiceType
+
+	// Endpoint is the RPC endpoint that returned the error
+	Endpoint Endpoint
+
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+
+	// Message is the error message returned by chia
 	Message string
 }
 
@@ -35,3 +47,28 @@ type ChiaRPCError struct {
 func (e *ChiaRPCError) Error() string {
 	return e.Message
 }
+
+// Is allows errors.Is to match ChiaRPCError against the error catalogue sentinels and consensus error codes
+func (e *ChiaRPCError) Is(target error) bool {
+	switch typed := target.(type) {
+	case *rpcError:
+		return typed.matches(e.Message)
+	case ConsensusErr:
+		for _, match := range consensusErrPattern.FindAllStringSubmatch(e.Message, -1) {
+			if ConsensusErr(match[1]) == typed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ConsensusErr returns the chia consensus error code included in the message, if there is one
+// push_tx failures look like "Failed to include transaction <id>, error DOUBLE_SPEND"
+func (e *ChiaRPCError) ConsensusErr() (ConsensusErr, bool) {
+	match := consensusErrPattern.FindStringSubmatch(e.Message)
+	if match == nil {
+		return "", false
+	}
+	return ConsensusErr(match[1]), true
+}
@@ -1,5 +1,7 @@
 package rpcinterface
 
+import "fmt"
+
 // ServiceType is a type that refers to a particular service
 type ServiceType uint8
 
@@ -31,3 +33,28 @@ const (
 	// ServiceDataLayer datalayer service
 	ServiceDataLayer
 )
+
+// String returns the name of the service as used in chia config and cert paths (full_node, wallet, etc)
+func (s ServiceType) String() string {
+	switch s {
+	case ServiceDaemon:
+		return "daemon"
+	case ServiceFullNode:
+		return "full_node"
+	case ServiceFarmer:
+		return "farmer"
+	case ServiceHarvester:
+		return "harvester"
+	case ServiceWallet:
+		return "wallet"
+	case ServiceTimelord:
+		return "timelord"
+	case ServicePeer:
+		return "peer"
+	case ServiceCrawler:
+		return "crawler"
+	case ServiceDataLayer:
+		return "data_layer"
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(s))
+}
@@ -0,0 +1,359 @@
+// Package rpctest provides an in-process fake of the chia RPC services for testing code that uses pkg/rpc
+//
+//	server := rpctest.NewServer(t)
+//	server.HandleJSON(rpcinterface.ServiceFullNode, "get_blockchain_state", `{"success": true, "blockchain_state": {...}}`)
+//	client, err := server.Client()
+package rpctest
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chia-network/go-chia-libs/pkg/config"
+	"github.com/chia-network/go-chia-libs/pkg/rpc"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	chiatls "github.com/chia-network/go-chia-libs/pkg/tls"
+)
+
+// Services are the services a Server starts by default. The daemon is websocket only, so it is not included
+var Services = []rpcinterface.ServiceType{
+	rpcinterface.ServiceFullNode,
+	rpcinterface.ServiceFarmer,
+	rpcinterface.ServiceHarvester,
+	rpcinterface.ServiceWallet,
+	rpcinterface.ServiceCrawler,
+	rpcinterface.ServiceDataLayer,
+	rpcinterface.ServiceTimelord,
+}
+
+var (
+	// Generating the full set of certs is slow, so it's only done once per test binary
+	certsOnce sync.Once
+	certs     *chiatls.ChiaCertificates
+	certsErr  error
+)
+
+// Request is an RPC request received by the Server
+type Request struct {
+	Service  rpcinterface.ServiceType
+	Endpoint rpcinterface.Endpoint
+	Body     []byte
+}
+
+// Decode unmarshals the request payload into v
+func (r *Request) Decode(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// HandlerFunc handles a request to an endpoint
+// The returned value is encoded to JSON as the response. If it doesn't set success, "success": true is added
+// Returning an error responds with "success": false and the error message, the same way chia reports RPC errors
+type HandlerFunc func(req *Request) (any, error)
+
+type route struct {
+	service  rpcinterface.ServiceType
+	endpoint rpcinterface.Endpoint
+}
+
+// Server runs an mTLS server for each chia service, using certs signed by a generated private CA
+type Server struct {
+	t testing.TB
+
+	servers map[rpcinterface.ServiceType]*httptest.Server
+	keyPair map[rpcinterface.ServiceType]*tls.Certificate
+
+	lock     sync.Mutex
+	handlers map[route]HandlerFunc
+	requests []*Request
+}
+
+// NewServer starts servers for the services, or for all Services when none are provided
+// The servers are closed when the test finishes
+func NewServer(t testing.TB, services ...rpcinterface.ServiceType) *Server {
+	t.Helper()
+
+	certsOnce.Do(func() {
+		certs, certsErr = chiatls.GenerateAllCerts(nil, nil)
+	})
+	if certsErr != nil {
+		t.Fatalf("rpctest: error generating certs: %s", certsErr.Error())
+	}
+
+	caCert, err := x509.ParseCertificate(certs.PrivateCA.CertificateDER)
+	if err != nil {
+		t.Fatalf("rpctest: error parsing private ca: %s", err.Error())
+	}
+	caPool := x509.NewCertPool()
+	caPool.AddCert(caCert)
+
+	if len(services) == 0 {
+		services = Services
+	}
+
+	s := &Server{
+		t:        t,
+		servers:  map[rpcinterface.ServiceType]*httptest.Server{},
+		keyPair:  map[rpcinterface.ServiceType]*tls.Certificate{},
+		handlers: map[route]HandlerFunc{},
+	}
+	t.Cleanup(s.Close)
+
+	for _, service := range services {
+		pair, err := keyPairForService(service)
+		if err != nil {
+			t.Fatalf("rpctest: %s", err.Error())
+		}
+
+		server := httptest.NewUnstartedServer(s.handler(service))
+		server.TLS = &tls.Config{
+			Certificates: []tls.Certificate{*pair},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    caPool,
+		}
+		server.Config.ErrorLog = log.New(io.Discard, "", 0)
+		server.StartTLS()
+
+		s.servers[service] = server
+		s.keyPair[service] = pair
+	}
+
+	return s
+}
+
+// keyPairForService returns the private cert and key for the service
+func keyPairForService(service rpcinterface.ServiceType) (*tls.Certificate, error) {
+	var pair *chiatls.CertificateKeyPair
+	switch service {
+	case rpcinterface.ServiceFullNode:
+		pair = certs.PrivateFullNode
+	case rpcinterface.ServiceFarmer:
+		pair = certs.PrivateFarmer
+	case rpcinterface.ServiceHarvester:
+		pair = certs.PrivateHarvester
+	case rpcinterface.ServiceWallet:
+		pair = certs.PrivateWallet
+	case rpcinterface.ServiceCrawler:
+		pair = certs.PrivateCrawler
+	case rpcinterface.ServiceDataLayer:
+		pair = certs.PrivateDatalayer
+	case rpcinterface.ServiceTimelord:
+		pair = certs.PrivateTimelord
+	default:
+		return nil, fmt.Errorf("service %s is not supported", service)
+	}
+
+	return &tls.Certificate{
+		Certificate: [][]byte{pair.CertificateDER},
+		PrivateKey:  pair.PrivateKey,
+	}, nil
+}
+
+// Handle registers a programmable handler for an endpoint, replacing any existing handler
+func (s *Server) Handle(service rpcinterface.ServiceType, endpoint rpcinterface.Endpoint, handler HandlerFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handlers[route{service: service, endpoint: endpoint}] = handler
+}
+
+// HandleJSON registers a canned JSON response for an endpoint
+func (s *Server) HandleJSON(service rpcinterface.ServiceType, endpoint rpcinterface.Endpoint, response string) {
+	s.Handle(service, endpoint, func(req *Request) (any, error) {
+		return json.RawMessage(response), nil
+	})
+}
+
+// Requests returns all requests received for the endpoint, in the order they were received
+func (s *Server) Requests(service rpcinterface.ServiceType, endpoint rpcinterface.Endpoint) []*Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var requests []*Request
+	for _, req := range s.requests {
+		if req.Service == service && req.Endpoint == endpoint {
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
+
+// AssertRequest fails the test unless the last request to the endpoint had the expected payload
+// expected can be a JSON string, or any value that encodes to the expected JSON, such as the rpc options struct
+func (s *Server) AssertRequest(service rpcinterface.ServiceType, endpoint rpcinterface.Endpoint, expected any) {
+	s.t.Helper()
+
+	requests := s.Requests(service, endpoint)
+	if len(requests) == 0 {
+		s.t.Errorf("rpctest: expected a request to %s/%s, but none were received", service, endpoint)
+		return
+	}
+	last := requests[len(requests)-1]
+
+	var expectedJSON []byte
+	switch typed := expected.(type) {
+	case string:
+		expectedJSON = []byte(typed)
+	case []byte:
+		expectedJSON = typed
+	default:
+		var err error
+		expectedJSON, err = json.Marshal(expected)
+		if err != nil {
+			s.t.Errorf("rpctest: error encoding expected payload: %s", err.Error())
+			return
+		}
+	}
+
+	var want, got any
+	if err := json.Unmarshal(expectedJSON, &want); err != nil {
+		s.t.Errorf("rpctest: expected payload is not valid JSON: %s", err.Error())
+		return
+	}
+	if err := json.Unmarshal(last.Body, &got); err != nil {
+		s.t.Errorf("rpctest: received payload for %s/%s is not valid JSON: %s", service, endpoint, err.Error())
+		return
+	}
+	if !reflect.DeepEqual(want, got) {
+		s.t.Errorf("rpctest: unexpected payload for %s/%s\nexpected: %s\nreceived: %s", service, endpoint, expectedJSON, last.Body)
+	}
+}
+
+// URL returns the URL of the server for the service
+func (s *Server) URL(service rpcinterface.ServiceType) *url.URL {
+	server, ok := s.servers[service]
+	if !ok {
+		return nil
+	}
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
+// Config returns a chia config with the ports of the running servers
+func (s *Server) Config() config.ChiaConfig {
+	port := func(service rpcinterface.ServiceType) config.PortConfig {
+		u := s.URL(service)
+		if u == nil {
+			return config.PortConfig{}
+		}
+		p, _ := strconv.ParseUint(u.Port(), 10, 16)
+		return config.PortConfig{RPCPort: uint16(p)}
+	}
+
+	return config.ChiaConfig{
+		FullNode:  config.FullNodeConfig{PortConfig: port(rpcinterface.ServiceFullNode)},
+		Farmer:    config.FarmerConfig{PortConfig: port(rpcinterface.ServiceFarmer)},
+		Harvester: config.HarvesterConfig{PortConfig: port(rpcinterface.ServiceHarvester)},
+		Wallet:    config.WalletConfig{PortConfig: port(rpcinterface.ServiceWallet)},
+		Seeder: config.SeederConfig{
+			CrawlerConfig: config.CrawlerConfig{PortConfig: port(rpcinterface.ServiceCrawler)},
+		},
+		DataLayer: config.DataLayerConfig{PortConfig: port(rpcinterface.ServiceDataLayer)},
+		Timelord:  config.TimelordConfig{PortConfig: port(rpcinterface.ServiceTimelord)},
+	}
+}
+
+// Client returns an HTTP mode rpc.Client connected to the servers, using the generated client certs
+// Any options are applied after the client is configured for the servers
+func (s *Server) Client(options ...rpcinterface.ClientOptionFunc) (*rpc.Client, error) {
+	clientOptions := []rpcinterface.ClientOptionFunc{
+		rpc.WithBaseURL(&url.URL{Scheme: "https", Host: "127.0.0.1"}),
+	}
+	for service, pair := range s.keyPair {
+		clientOptions = append(clientOptions, rpc.WithServiceKeyPair(service, pair))
+	}
+	clientOptions = append(clientOptions, options...)
+
+	return rpc.NewClient(rpc.ConnectionModeHTTP, rpc.WithManualConfig(s.Config()), clientOptions...)
+}
+
+// Close shuts down all servers
+func (s *Server) Close() {
+	for _, server := range s.servers {
+		server.Close()
+	}
+}
+
+// handler returns the http.Handler for the service
+func (s *Server) handler(service rpcinterface.ServiceType) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		req := &Request{
+			Service:  service,
+			Endpoint: rpcinterface.Endpoint(strings.TrimPrefix(r.URL.Path, "/")),
+			Body:     body,
+		}
+
+		s.lock.Lock()
+		s.requests = append(s.requests, req)
+		handler, ok := s.handlers[route{service: service, endpoint: req.Endpoint}]
+		s.lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			writeError(w, fmt.Sprintf("rpctest: no handler registered for %s/%s", service, req.Endpoint))
+			return
+		}
+
+		response, err := handler(req)
+		if err != nil {
+			writeError(w, err.Error())
+			return
+		}
+
+		encoded, err := encodeResponse(response)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			writeError(w, fmt.Sprintf("rpctest: error encoding response: %s", err.Error()))
+			return
+		}
+		_, _ = w.Write(encoded)
+	})
+}
+
+// encodeResponse encodes the handler response, adding "success": true to objects that don't set success
+func encodeResponse(response any) ([]byte, error) {
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil || fields == nil {
+		// Not an object, so it's sent as-is
+		return encoded, nil
+	}
+	if _, ok := fields["success"]; ok {
+		return encoded, nil
+	}
+	fields["success"] = json.RawMessage(`true`)
+
+	return json.Marshal(fields)
+}
+
+// writeError writes a chia style RPC error
+func writeError(w io.Writer, message string) {
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"success": false,
+		"error":   message,
+	})
+}
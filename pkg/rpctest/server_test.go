@@ -0,0 +1,63 @@
+package rpctest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/chia-network/go-chia-libs/pkg/rpc"
+	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
+	"github.com/chia-network/go-chia-libs/pkg/rpctest"
+)
+
+func TestServer(t *testing.T) {
+	server := rpctest.NewServer(t)
+	client, err := server.Client()
+	require.NoError(t, err)
+
+	server.HandleJSON(rpcinterface.ServiceFullNode, "get_blockchain_state", `{"blockchain_state": {"difficulty": 42}}`)
+
+	balances := map[uint32]uint64{1: 1000, 2: 50}
+	server.Handle(rpcinterface.ServiceWallet, "get_wallet_balance", func(req *rpctest.Request) (any, error) {
+		opts := &rpc.GetWalletBalanceOptions{}
+		if err := req.Decode(opts); err != nil {
+			return nil, err
+		}
+		balance, ok := balances[opts.WalletID]
+		if !ok {
+			return nil, errors.New("wallet not found")
+		}
+		return map[string]any{"wallet_balance": map[string]any{"wallet_id": opts.WalletID, "confirmed_wallet_balance": balance}}, nil
+	})
+
+	state, _, err := client.FullNodeService.GetBlockchainState()
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), state.BlockchainState.MustGet().Difficulty)
+
+	balance, _, err := client.WalletService.GetWalletBalance(&rpc.GetWalletBalanceOptions{WalletID: 2})
+	require.NoError(t, err)
+	require.Equal(t, uint64(50), balance.Balance.MustGet().ConfirmedWalletBalance.Uint64())
+	server.AssertRequest(rpcinterface.ServiceWallet, "get_wallet_balance", `{"wallet_id": 2}`)
+	server.AssertRequest(rpcinterface.ServiceWallet, "get_wallet_balance", &rpc.GetWalletBalanceOptions{WalletID: 2})
+
+	_, _, err = client.WalletService.GetWalletBalance(&rpc.GetWalletBalanceOptions{WalletID: 3})
+	require.EqualError(t, err, "wallet not found")
+	require.Len(t, server.Requests(rpcinterface.ServiceWallet, "get_wallet_balance"), 2)
+
+	// Endpoints without a handler return an RPC error
+	_, _, err = client.FarmerService.GetHarvesters(&rpc.FarmerGetHarvestersOptions{})
+	require.ErrorContains(t, err, "no handler registered for farmer/get_harvesters")
+}
+
+func TestServerRequiresClientCert(t *testing.T) {
+	server := rpctest.NewServer(t, rpcinterface.ServiceFullNode)
+	server.HandleJSON(rpcinterface.ServiceFullNode, "get_blockchain_state", `{}`)
+
+	client, err := rpc.NewClient(rpc.ConnectionModePublicHTTP, rpc.WithPublicConfig(), rpc.WithBaseURL(server.URL(rpcinterface.ServiceFullNode)))
+	require.NoError(t, err)
+
+	_, _, err = client.FullNodeService.GetBlockchainState()
+	require.Error(t, err)
+	require.Nil(t, server.URL(rpcinterface.ServiceWallet))
+}
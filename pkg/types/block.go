@@ -85,6 +85,31 @@ type RewardChainBlock struct {
 	IsTransactionBlock         bool               `json:"is_transaction_block" streamable:""`
 }
 
+// RewardChainBlockUnfinished is the reward chain block of an unfinished block, before the infusion point VDFs
+// https://github.com/Chia-Network/chia_rs/blob/main/crates/chia-protocol/src/reward_chain_block.rs#L11
+type RewardChainBlockUnfinished struct {
+	TotalIters                Uint128            `json:"total_iters" streamable:""`
+	SignagePointIndex         uint8              `json:"signage_point_index" streamable:""`
+	POSSSCCChallengeHash      Bytes32            `json:"pos_ss_cc_challenge_hash" streamable:""`
+	ProofOfSpace              ProofOfSpace       `json:"proof_of_space" streamable:""`
+	ChallengeChainSPVDF       mo.Option[VDFInfo] `json:"challenge_chain_sp_vdf" streamable:""`
+	ChallengeChainSPSignature G2Element          `json:"challenge_chain_sp_signature" streamable:""`
+	RewardChainSPVDF          mo.Option[VDFInfo] `json:"reward_chain_sp_vdf" streamable:""`
+	RewardChainSPSignature    G2Element          `json:"reward_chain_sp_signature" streamable:""`
+}
+
+// UnfinishedHeaderBlock is the header of a block that has not been infused yet
+// https://github.com/Chia-Network/chia_rs/blob/main/crates/chia-protocol/src/unfinished_header_block.rs#L10
+type UnfinishedHeaderBlock struct {
+	FinishedSubSlots        []EndOfSubSlotBundle               `json:"finished_sub_slots" streamable:""`
+	RewardChainBlock        RewardChainBlockUnfinished         `json:"reward_chain_block" streamable:""`
+	ChallengeChainSPProof   mo.Option[VDFProof]                `json:"challenge_chain_sp_proof" streamable:""`
+	RewardChainSPProof      mo.Option[VDFProof]                `json:"reward_chain_sp_proof" streamable:""`
+	Foliage                 Foliage                            `json:"foliage" streamable:""`
+	FoliageTransactionBlock mo.Option[FoliageTransactionBlock] `json:"foliage_transaction_block" streamable:""`
+	TransactionsFilter      Bytes                              `json:"transactions_filter" streamable:""`
+}
+
 // BlockCountMetrics metrics from get_block_count_metrics endpoint
 // https://github.com/Chia-Network/chia-blockchain/blob/0befdec071f49708e26c7638656874492c52600a/chia/rpc/full_node_rpc_api.py#L382
 // Types are `int` in python, which is apparently unlimited in python3. Using uint64 as the largest native int in go
@@ -0,0 +1,147 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ConditionOpcode is the opcode of a condition output by a puzzle
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/types/condition_opcodes.py
+type ConditionOpcode uint8
+
+// Condition opcodes
+const (
+	ConditionOpcodeRemark                      ConditionOpcode = 1
+	ConditionOpcodeAggSigParent                ConditionOpcode = 43
+	ConditionOpcodeAggSigPuzzle                ConditionOpcode = 44
+	ConditionOpcodeAggSigAmount                ConditionOpcode = 45
+	ConditionOpcodeAggSigPuzzleAmount          ConditionOpcode = 46
+	ConditionOpcodeAggSigParentAmount          ConditionOpcode = 47
+	ConditionOpcodeAggSigParentPuzzle          ConditionOpcode = 48
+	ConditionOpcodeAggSigUnsafe                ConditionOpcode = 49
+	ConditionOpcodeAggSigMe                    ConditionOpcode = 50
+	ConditionOpcodeCreateCoin                  ConditionOpcode = 51
+	ConditionOpcodeReserveFee                  ConditionOpcode = 52
+	ConditionOpcodeCreateCoinAnnouncement      ConditionOpcode = 60
+	ConditionOpcodeAssertCoinAnnouncement      ConditionOpcode = 61
+	ConditionOpcodeCreatePuzzleAnnouncement    ConditionOpcode = 62
+	ConditionOpcodeAssertPuzzleAnnouncement    ConditionOpcode = 63
+	ConditionOpcodeAssertConcurrentSpend       ConditionOpcode = 64
+	ConditionOpcodeAssertConcurrentPuzzle      ConditionOpcode = 65
+	ConditionOpcodeSendMessage                 ConditionOpcode = 66
+	ConditionOpcodeReceiveMessage              ConditionOpcode = 67
+	ConditionOpcodeAssertMyCoinID              ConditionOpcode = 70
+	ConditionOpcodeAssertMyParentID            ConditionOpcode = 71
+	ConditionOpcodeAssertMyPuzzleHash          ConditionOpcode = 72
+	ConditionOpcodeAssertMyAmount              ConditionOpcode = 73
+	ConditionOpcodeAssertMyBirthSeconds        ConditionOpcode = 74
+	ConditionOpcodeAssertMyBirthHeight         ConditionOpcode = 75
+	ConditionOpcodeAssertEphemeral             ConditionOpcode = 76
+	ConditionOpcodeAssertSecondsRelative       ConditionOpcode = 80
+	ConditionOpcodeAssertSecondsAbsolute       ConditionOpcode = 81
+	ConditionOpcodeAssertHeightRelative        ConditionOpcode = 82
+	ConditionOpcodeAssertHeightAbsolute        ConditionOpcode = 83
+	ConditionOpcodeAssertBeforeSecondsRelative ConditionOpcode = 84
+	ConditionOpcodeAssertBeforeSecondsAbsolute ConditionOpcode = 85
+	ConditionOpcodeAssertBeforeHeightRelative  ConditionOpcode = 86
+	ConditionOpcodeAssertBeforeHeightAbsolute  ConditionOpcode = 87
+	ConditionOpcodeSoftfork                    ConditionOpcode = 90
+)
+
+// conditionOpcodeNames are the names chia uses for each opcode
+var conditionOpcodeNames = map[ConditionOpcode]string{
+	ConditionOpcodeRemark:                      "REMARK",
+	ConditionOpcodeAggSigParent:                "AGG_SIG_PARENT",
+	ConditionOpcodeAggSigPuzzle:                "AGG_SIG_PUZZLE",
+	ConditionOpcodeAggSigAmount:                "AGG_SIG_AMOUNT",
+	ConditionOpcodeAggSigPuzzleAmount:          "AGG_SIG_PUZZLE_AMOUNT",
+	ConditionOpcodeAggSigParentAmount:          "AGG_SIG_PARENT_AMOUNT",
+	ConditionOpcodeAggSigParentPuzzle:          "AGG_SIG_PARENT_PUZZLE",
+	ConditionOpcodeAggSigUnsafe:                "AGG_SIG_UNSAFE",
+	ConditionOpcodeAggSigMe:                    "AGG_SIG_ME",
+	ConditionOpcodeCreateCoin:                  "CREATE_COIN",
+	ConditionOpcodeReserveFee:                  "RESERVE_FEE",
+	ConditionOpcodeCreateCoinAnnouncement:      "CREATE_COIN_ANNOUNCEMENT",
+	ConditionOpcodeAssertCoinAnnouncement:      "ASSERT_COIN_ANNOUNCEMENT",
+	ConditionOpcodeCreatePuzzleAnnouncement:    "CREATE_PUZZLE_ANNOUNCEMENT",
+	ConditionOpcodeAssertPuzzleAnnouncement:    "ASSERT_PUZZLE_ANNOUNCEMENT",
+	ConditionOpcodeAssertConcurrentSpend:       "ASSERT_CONCURRENT_SPEND",
+	ConditionOpcodeAssertConcurrentPuzzle:      "ASSERT_CONCURRENT_PUZZLE",
+	ConditionOpcodeSendMessage:                 "SEND_MESSAGE",
+	ConditionOpcodeReceiveMessage:              "RECEIVE_MESSAGE",
+	ConditionOpcodeAssertMyCoinID:              "ASSERT_MY_COIN_ID",
+	ConditionOpcodeAssertMyParentID:            "ASSERT_MY_PARENT_ID",
+	ConditionOpcodeAssertMyPuzzleHash:          "ASSERT_MY_PUZZLEHASH",
+	ConditionOpcodeAssertMyAmount:              "ASSERT_MY_AMOUNT",
+	ConditionOpcodeAssertMyBirthSeconds:        "ASSERT_MY_BIRTH_SECONDS",
+	ConditionOpcodeAssertMyBirthHeight:         "ASSERT_MY_BIRTH_HEIGHT",
+	ConditionOpcodeAssertEphemeral:             "ASSERT_EPHEMERAL",
+	ConditionOpcodeAssertSecondsRelative:       "ASSERT_SECONDS_RELATIVE",
+	ConditionOpcodeAssertSecondsAbsolute:       "ASSERT_SECONDS_ABSOLUTE",
+	ConditionOpcodeAssertHeightRelative:        "ASSERT_HEIGHT_RELATIVE",
+	ConditionOpcodeAssertHeightAbsolute:        "ASSERT_HEIGHT_ABSOLUTE",
+	ConditionOpcodeAssertBeforeSecondsRelative: "ASSERT_BEFORE_SECONDS_RELATIVE",
+	ConditionOpcodeAssertBeforeSecondsAbsolute: "ASSERT_BEFORE_SECONDS_ABSOLUTE",
+	ConditionOpcodeAssertBeforeHeightRelative:  "ASSERT_BEFORE_HEIGHT_RELATIVE",
+	ConditionOpcodeAssertBeforeHeightAbsolute:  "ASSERT_BEFORE_HEIGHT_ABSOLUTE",
+	ConditionOpcodeSoftfork:                    "SOFTFORK",
+}
+
+// String returns the name chia uses for the opcode, such as CREATE_COIN
+func (c ConditionOpcode) String() string {
+	if name, ok := conditionOpcodeNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint8(c))
+}
+
+// MarshalJSON marshals the opcode as hex bytes, the same as chia
+func (c ConditionOpcode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("0x%02x", uint8(c)))
+}
+
+// UnmarshalJSON unmarshals the opcode from hex bytes, a number, or the opcode name
+func (c *ConditionOpcode) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if v < 0 || v > 255 || v != float64(uint8(v)) {
+			return fmt.Errorf("invalid condition opcode %v", v)
+		}
+		*c = ConditionOpcode(v)
+		return nil
+	case string:
+		for opcode, name := range conditionOpcodeNames {
+			if name == v {
+				*c = opcode
+				return nil
+			}
+		}
+		opcode, err := strconv.ParseUint(strings.TrimPrefix(v, "0x"), 16, 8)
+		if err != nil {
+			return fmt.Errorf("invalid condition opcode %q", v)
+		}
+		*c = ConditionOpcode(opcode)
+		return nil
+	}
+	return fmt.Errorf("invalid condition opcode %s", data)
+}
+
+// ConditionWithArgs is a condition and its arguments
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/types/condition_with_args.py#L12
+type ConditionWithArgs struct {
+	Opcode ConditionOpcode `json:"opcode"`
+	Vars   []Bytes         `json:"vars"`
+}
+
+// CoinSpendWithConditions is a coin spend and the conditions output by running it
+type CoinSpendWithConditions struct {
+	CoinSpend  CoinSpend           `json:"coin_spend"`
+	Conditions []ConditionWithArgs `json:"conditions"`
+}
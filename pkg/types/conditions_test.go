@@ -0,0 +1,27 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+func TestConditionOpcodeJSON(t *testing.T) {
+	for _, input := range []string{`"0x33"`, `51`, `"CREATE_COIN"`} {
+		var opcode types.ConditionOpcode
+		assert.NoError(t, json.Unmarshal([]byte(input), &opcode), input)
+		assert.Equal(t, types.ConditionOpcodeCreateCoin, opcode, input)
+	}
+
+	var opcode types.ConditionOpcode
+	assert.Error(t, json.Unmarshal([]byte(`"NOT_A_CONDITION"`), &opcode))
+	assert.Error(t, json.Unmarshal([]byte(`256`), &opcode))
+
+	b, err := json.Marshal(types.ConditionOpcodeAggSigMe)
+	assert.NoError(t, err)
+	assert.Equal(t, `"0x32"`, string(b))
+	assert.Equal(t, "AGG_SIG_ME", types.ConditionOpcodeAggSigMe.String())
+}
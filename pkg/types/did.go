@@ -0,0 +1,28 @@
+package types
+
+import (
+	"github.com/samber/mo"
+)
+
+// DIDMetadata is the metadata stored in a DID as key value pairs
+type DIDMetadata map[string]string
+
+// DIDInfo is the on chain state of a DID, as returned by did_get_info
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/rpc/wallet_rpc_api.py
+type DIDInfo struct {
+	// DIDID is the did:chia: identifier of the DID
+	DIDID mo.Option[string] `json:"did_id"`
+
+	// LatestCoin is the ID of the current singleton coin of the DID
+	LatestCoin mo.Option[Bytes32] `json:"latest_coin"`
+
+	// P2Address is the address of the inner puzzle that controls the DID
+	P2Address        mo.Option[string]            `json:"p2_address"`
+	PublicKey        mo.Option[G1Element]         `json:"public_key"`
+	RecoveryListHash mo.Option[Bytes32]           `json:"recovery_list_hash"`
+	NumVerification  mo.Option[uint64]            `json:"num_verification"`
+	Metadata         mo.Option[DIDMetadata]       `json:"metadata"`
+	LauncherID       mo.Option[Bytes32]           `json:"launcher_id"`
+	FullPuzzle       mo.Option[SerializedProgram] `json:"full_puzzle"`
+	Hints            mo.Option[[]Bytes]           `json:"hints"`
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"github.com/samber/mo"
+
+	"github.com/chia-network/go-chia-libs/pkg/tuple"
+)
+
+// MempoolItem is a spend bundle in the mempool, with the result of running it
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/types/mempool_item.py
+type MempoolItem struct {
+	SpendBundle     SpendBundle `json:"spend_bundle"`
+	Fee             uint64      `json:"fee"`
+	NPCResult       NPCResult   `json:"npc_result"`
+	Cost            uint64      `json:"cost"`
+	SpendBundleName Bytes32     `json:"spend_bundle_name"`
+	Additions       []Coin      `json:"additions"`
+	Removals        []Coin      `json:"removals"`
+
+	// The following are only included by some versions of chia
+	HeightAddedToMempool mo.Option[uint32] `json:"height_added_to_mempool"`
+	AssertHeight         mo.Option[uint32] `json:"assert_height"`
+	AssertBeforeHeight   mo.Option[uint32] `json:"assert_before_height"`
+	AssertBeforeSeconds  mo.Option[uint64] `json:"assert_before_seconds"`
+}
+
+// FeePerCost returns the fee paid per unit of cost, which is how the mempool prioritizes items
+func (m *MempoolItem) FeePerCost() float64 {
+	if m.Cost == 0 {
+		return 0
+	}
+	return float64(m.Fee) / float64(m.Cost)
+}
+
+// NPCResult is the result of running a spend bundle
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/consensus/cost_calculator.py
+type NPCResult struct {
+	// Error is the chia.util.errors.Err code, if running the spend bundle failed
+	Error mo.Option[uint16]                `json:"error"`
+	Conds mo.Option[SpendBundleConditions] `json:"conds"`
+}
+
+// SpendBundleConditions are the conditions output by all spends in a spend bundle
+// https://github.com/Chia-Network/chia_rs/blob/main/crates/chia-consensus/src/gen/owned_conditions.rs
+type SpendBundleConditions struct {
+	Spends                []SpendConditions              `json:"spends"`
+	ReserveFee            uint64                         `json:"reserve_fee"`
+	HeightAbsolute        uint32                         `json:"height_absolute"`
+	SecondsAbsolute       uint64                         `json:"seconds_absolute"`
+	BeforeHeightAbsolute  mo.Option[uint32]              `json:"before_height_absolute"`
+	BeforeSecondsAbsolute mo.Option[uint64]              `json:"before_seconds_absolute"`
+	AggSigUnsafe          []tuple.Tuple[AggSigCondition] `json:"agg_sig_unsafe"` // List[Tuple[G1Element, bytes]]
+	Cost                  uint64                         `json:"cost"`
+	RemovalAmount         Uint128                        `json:"removal_amount"`
+	AdditionAmount        Uint128                        `json:"addition_amount"`
+}
+
+// SpendConditions are the conditions output by a single coin spend
+type SpendConditions struct {
+	CoinID                Bytes32                        `json:"coin_id"`
+	ParentID              Bytes32                        `json:"parent_id"`
+	PuzzleHash            Bytes32                        `json:"puzzle_hash"`
+	CoinAmount            uint64                         `json:"coin_amount"`
+	HeightRelative        mo.Option[uint32]              `json:"height_relative"`
+	SecondsRelative       mo.Option[uint64]              `json:"seconds_relative"`
+	BeforeHeightRelative  mo.Option[uint32]              `json:"before_height_relative"`
+	BeforeSecondsRelative mo.Option[uint64]              `json:"before_seconds_relative"`
+	BirthHeight           mo.Option[uint32]              `json:"birth_height"`
+	BirthSeconds          mo.Option[uint64]              `json:"birth_seconds"`
+	CreateCoin            []tuple.Tuple[CreateCoin]      `json:"create_coin"` // List[Tuple[bytes32, uint64, Optional[bytes]]]
+	AggSigMe              []tuple.Tuple[AggSigCondition] `json:"agg_sig_me"`  // List[Tuple[G1Element, bytes]]
+	Flags                 uint32                         `json:"flags"`
+}
+
+// CreateCoin is a coin created by a spend, with its hint if there was one
+type CreateCoin struct {
+	PuzzleHash Bytes32
+	Amount     uint64
+	Hint       mo.Option[Bytes]
+}
+
+// AggSigCondition is a public key and message that must be signed for a spend to be valid
+type AggSigCondition struct {
+	PublicKey G1Element
+	Message   Bytes
+}
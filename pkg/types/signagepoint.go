@@ -1,5 +1,7 @@
 package types
 
+import "github.com/samber/mo"
+
 // SignagePointEvent is the data received for each signage point
 // https://github.com/Chia-Network/chia-blockchain/blob/main/chia/full_node/full_node.py#L1413
 type SignagePointEvent struct {
@@ -18,3 +20,13 @@ type NewSignagePoint struct {
 	SubSlotIters       uint64  `json:"sub_slot_iters"`
 	SignagePointIndex  uint8   `json:"signage_point_index"`
 }
+
+// SignagePoint is the VDFs for a signage point, as stored by the full node
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/full_node/signage_point.py#L11
+// @TODO Streamable
+type SignagePoint struct {
+	CCVDF   mo.Option[VDFInfo]  `json:"cc_vdf"`
+	CCProof mo.Option[VDFProof] `json:"cc_proof"`
+	RCVDF   mo.Option[VDFInfo]  `json:"rc_vdf"`
+	RCProof mo.Option[VDFProof] `json:"rc_proof"`
+}
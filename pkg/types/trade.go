@@ -0,0 +1,148 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/samber/mo"
+
+	"github.com/chia-network/go-chia-libs/pkg/tuple"
+)
+
+// TradeStatus is the state of an offer
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/wallet/trading/trade_status.py
+type TradeStatus uint32
+
+const (
+	// TradeStatusPendingAccept the offer has been created, and hasn't been taken or cancelled
+	TradeStatusPendingAccept TradeStatus = 0
+
+	// TradeStatusPendingConfirm the offer has been taken, and is waiting to be confirmed on chain
+	TradeStatusPendingConfirm TradeStatus = 1
+
+	// TradeStatusPendingCancel the offer is being cancelled on chain
+	TradeStatusPendingCancel TradeStatus = 2
+
+	// TradeStatusCancelled the offer has been cancelled
+	TradeStatusCancelled TradeStatus = 3
+
+	// TradeStatusConfirmed the trade has been confirmed on chain
+	TradeStatusConfirmed TradeStatus = 4
+
+	// TradeStatusFailed the trade failed
+	TradeStatusFailed TradeStatus = 5
+)
+
+// tradeStatusNames are the names chia uses for each trade status
+var tradeStatusNames = map[TradeStatus]string{
+	TradeStatusPendingAccept:  "PENDING_ACCEPT",
+	TradeStatusPendingConfirm: "PENDING_CONFIRM",
+	TradeStatusPendingCancel:  "PENDING_CANCEL",
+	TradeStatusCancelled:      "CANCELLED",
+	TradeStatusConfirmed:      "CONFIRMED",
+	TradeStatusFailed:         "FAILED",
+}
+
+// String returns the name chia uses for the status, such as PENDING_ACCEPT
+func (s TradeStatus) String() string {
+	if name, ok := tradeStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint32(s))
+}
+
+// MarshalJSON marshals the status as its name, the same as the wallet RPC
+func (s TradeStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON unmarshals the status from its name, or from the number used in the streamable trade record
+func (s *TradeStatus) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if v < 0 || v != float64(uint32(v)) {
+			return fmt.Errorf("invalid trade status %v", v)
+		}
+		*s = TradeStatus(v)
+		return nil
+	case string:
+		for status, name := range tradeStatusNames {
+			if name == v {
+				*s = status
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("invalid trade status %s", data)
+}
+
+// IsPending returns true if the trade hasn't reached a final state yet
+func (s TradeStatus) IsPending() bool {
+	return s == TradeStatusPendingAccept || s == TradeStatusPendingConfirm || s == TradeStatusPendingCancel
+}
+
+// TradeRecord is an offer the wallet created or took
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/wallet/trade_record.py
+// @TODO Streamable
+type TradeRecord struct {
+	ConfirmedAtIndex uint32                         `json:"confirmed_at_index"`
+	AcceptedAtTime   mo.Option[Timestamp]           `json:"accepted_at_time"`
+	CreatedAtTime    Timestamp                      `json:"created_at_time"`
+	IsMyOffer        bool                           `json:"is_my_offer"`
+	Sent             uint32                         `json:"sent"`
+	TakenOffer       mo.Option[Bytes]               `json:"taken_offer"`
+	CoinsOfInterest  []Coin                         `json:"coins_of_interest"`
+	TradeID          Bytes32                        `json:"trade_id"`
+	Status           TradeStatus                    `json:"status"`
+	SentTo           []tuple.Tuple[SentTo]          `json:"sent_to"` // List[Tuple[str, uint8, Optional[str]]]
+	ValidTimes       mo.Option[ConditionValidTimes] `json:"valid_times"`
+
+	// Summary and Pending are added to the trade record by the wallet RPC, and are not part of the streamable type
+	// Pending is the amount of each asset locked up by the offer while it is pending
+	Summary mo.Option[OfferSummary] `json:"summary"`
+	Pending map[string]uint64       `json:"pending"`
+}
+
+// OfferSummary is what an offer gives and asks for
+// Assets are keyed by "xch", the CAT asset ID, or the NFT launcher ID
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/wallet/trading/offer.py
+type OfferSummary struct {
+	Offered   map[string]uint64     `json:"offered"`
+	Requested map[string]uint64     `json:"requested"`
+	Infos     map[string]PuzzleInfo `json:"infos"`
+	Fees      uint64                `json:"fees"`
+
+	// The following are only included by get_offer_summary
+	Additions  []Bytes32                      `json:"additions,omitempty"`
+	Removals   []Bytes32                      `json:"removals,omitempty"`
+	ValidTimes mo.Option[ConditionValidTimes] `json:"valid_times"`
+}
+
+// PuzzleInfo describes the puzzle driver for an asset in an offer, such as {"type": "CAT", "tail": "0x..."}
+// The keys depend on the type of asset, and may be nested, so they are left as generic JSON values
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/wallet/puzzle_drivers.py
+type PuzzleInfo map[string]any
+
+// Type returns the type of the asset, such as CAT or singleton
+func (p PuzzleInfo) Type() string {
+	t, _ := p["type"].(string)
+	return t
+}
+
+// ConditionValidTimes are the time and height bounds on when the spends in an offer are valid
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/wallet/conditions.py
+type ConditionValidTimes struct {
+	MinSecsSinceCreated   mo.Option[uint64] `json:"min_secs_since_created"`
+	MinTime               mo.Option[uint64] `json:"min_time"`
+	MinBlocksSinceCreated mo.Option[uint32] `json:"min_blocks_since_created"`
+	MinHeight             mo.Option[uint32] `json:"min_height"`
+	MaxSecsAfterCreated   mo.Option[uint64] `json:"max_secs_after_created"`
+	MaxTime               mo.Option[uint64] `json:"max_time"`
+	MaxBlocksAfterCreated mo.Option[uint32] `json:"max_blocks_after_created"`
+	MaxHeight             mo.Option[uint32] `json:"max_height"`
+}
@@ -0,0 +1,26 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chia-network/go-chia-libs/pkg/types"
+)
+
+func TestTradeStatusJSON(t *testing.T) {
+	for _, input := range []string{`"CANCELLED"`, `3`} {
+		var status types.TradeStatus
+		assert.NoError(t, json.Unmarshal([]byte(input), &status), input)
+		assert.Equal(t, types.TradeStatusCancelled, status, input)
+		assert.False(t, status.IsPending())
+	}
+
+	var status types.TradeStatus
+	assert.Error(t, json.Unmarshal([]byte(`"NOT_A_STATUS"`), &status))
+
+	b, err := json.Marshal(types.TradeStatusPendingConfirm)
+	assert.NoError(t, err)
+	assert.Equal(t, `"PENDING_CONFIRM"`, string(b))
+}
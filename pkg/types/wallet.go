@@ -44,6 +44,12 @@ const (
 
 	// WalletTypeDataLayerOffer Data Layer Offer wallet
 	WalletTypeDataLayerOffer = WalletType(12)
+
+	// WalletTypeVC Verifiable Credential wallet
+	WalletTypeVC = WalletType(13)
+
+	// WalletTypeCRCAT Credential Restricted CAT wallet
+	WalletTypeCRCAT = WalletType(57)
 )
 
 // WalletInfo single wallet record
@@ -71,3 +77,11 @@ type WalletBalance struct {
 	WalletType               WalletType `json:"wallet_type"`
 	AssetID                  string     `json:"asset_id"`
 }
+
+// CAT is a well known CAT, as returned by get_cat_list
+// https://github.com/Chia-Network/chia-blockchain/blob/main/chia/wallet/cat_wallet/cat_constants.py
+type CAT struct {
+	AssetID Bytes32 `json:"asset_id"`
+	Name    string  `json:"name"`
+	Symbol  string  `json:"symbol"`
+}
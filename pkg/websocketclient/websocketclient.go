@@ -12,12 +12,14 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 
 	"github.com/chia-network/go-chia-libs/pkg/config"
+	"github.com/chia-network/go-chia-libs/pkg/metrics"
 	"github.com/chia-network/go-chia-libs/pkg/rpcinterface"
 	"github.com/chia-network/go-chia-libs/pkg/types"
 	"github.com/chia-network/go-chia-libs/pkg/util"
@@ -30,6 +32,9 @@ type WebsocketClient struct {
 	logger  *slog.Logger
 	origin  string
 
+	// interceptors wrap every call to Do
+	interceptors []rpcinterface.Interceptor
+
 	// Request timeout
 	Timeout time.Duration
 
@@ -37,6 +42,9 @@ type WebsocketClient struct {
 	daemonKeyPair *tls.Certificate
 	daemonDialer  *websocket.Dialer
 
+	// If set, the connection to the daemon is opened with dialContext
+	dialContext rpcinterface.DialContextFunc
+
 	conn *websocket.Conn
 	lock sync.Mutex
 
@@ -60,6 +68,12 @@ type WebsocketClient struct {
 
 	disconnectHandlers []rpcinterface.DisconnectHandler
 	reconnectHandlers  []rpcinterface.ReconnectHandler
+
+	// If set, reconnects and the handler queue depth are reported to metrics
+	metrics metrics.Recorder
+
+	// handlerQueueDepth is the number of messages that have not finished being passed to the handlers
+	handlerQueueDepth atomic.Int64
 }
 
 // NewWebsocketClient returns a new websocket client that satisfies the rpcinterface.Client interface
@@ -122,6 +136,21 @@ func (c *WebsocketClient) SetLogHandler(handler slog.Handler) {
 	c.logger = slog.New(handler)
 }
 
+// SetDialer sets the function used to open the connection to the daemon, such as to connect through a unix socket
+func (c *WebsocketClient) SetDialer(dial rpcinterface.DialContextFunc) {
+	c.dialContext = dial
+}
+
+// SetMetrics sets the recorder that reconnects and the handler queue depth are reported to
+func (c *WebsocketClient) SetMetrics(recorder metrics.Recorder) {
+	c.metrics = recorder
+}
+
+// AddInterceptor adds an interceptor that wraps every request made with Do
+func (c *WebsocketClient) AddInterceptor(interceptor rpcinterface.Interceptor) {
+	c.interceptors = append(c.interceptors, interceptor)
+}
+
 // NewRequest creates an RPC request for the specified service
 func (c *WebsocketClient) NewRequest(service rpcinterface.ServiceType, rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
 	request := &rpcinterface.Request{
@@ -137,8 +166,15 @@ func (c *WebsocketClient) NewRequest(service rpcinterface.ServiceType, rpcEndpoi
 // Do sends an RPC request via the websocket
 // *http.Response is always nil in this return in async mode
 // call SetSyncMode() to ensure the calls return the data in a synchronous fashion
+// The request context is honored while connecting and, in sync mode, while waiting for the response
 func (c *WebsocketClient) Do(req *rpcinterface.Request, v rpcinterface.IResponse) (*http.Response, error) {
-	err := c.ensureConnection()
+	req.Async = !c.syncMode
+	return rpcinterface.ChainInterceptors(c.interceptors, c.do)(req, v)
+}
+
+// do sends the request without any interceptors applied
+func (c *WebsocketClient) do(req *rpcinterface.Request, v rpcinterface.IResponse) (*http.Response, error) {
+	err := c.ensureConnectionContext(req.Context())
 	if err != nil {
 		return nil, fmt.Errorf("error ensuring connection: %w", err)
 	}
@@ -182,7 +218,7 @@ func (c *WebsocketClient) Do(req *rpcinterface.Request, v rpcinterface.IResponse
 		return nil, err
 	}
 
-	return c.responseHelper(request, v)
+	return c.responseHelper(req.Context(), request, v)
 }
 
 // Close closes the client/websocket
@@ -199,15 +235,17 @@ func (c *WebsocketClient) Close() error {
 // responseHelper implements the logic to either immediately return in async mode
 // or to wait for the expected response up to the defined timeout and returns the
 // response in a synchronous fashion
-func (c *WebsocketClient) responseHelper(request *types.WebsocketRequest, v interface{}) (*http.Response, error) {
+// The wait is also aborted if the parent context is cancelled or its deadline is reached first
+func (c *WebsocketClient) responseHelper(parent context.Context, request *types.WebsocketRequest, v interface{}) (*http.Response, error) {
 	if !c.syncMode {
 		return nil, nil
 	}
 	// We're in sync mode, so wait up to the timeout for the desired response, or else return an error
 
-	errChan := make(chan error)
-	doneChan := make(chan bool)
-	ctx, cancelCtx := context.WithTimeout(context.Background(), c.Timeout)
+	// Buffered so the handler never blocks if we've already stopped waiting
+	errChan := make(chan error, 1)
+	doneChan := make(chan bool, 1)
+	ctx, cancelCtx := context.WithTimeout(parent, c.Timeout)
 	defer cancelCtx()
 
 	// Set up a handler to process responses and keep an eye out for the right one
@@ -223,6 +261,7 @@ func (c *WebsocketClient) responseHelper(request *types.WebsocketRequest, v inte
 				}
 				if err != nil {
 					errChan <- err
+					return
 				}
 			}
 			doneChan <- true
@@ -244,6 +283,10 @@ func (c *WebsocketClient) responseHelper(request *types.WebsocketRequest, v inte
 				return nil, nil
 			}
 		case <-ctx.Done():
+			c.RemoveHandler(handlerID)
+			if parent.Err() != nil {
+				return nil, parent.Err()
+			}
 			return nil, fmt.Errorf("timeout of %s reached before getting a response", c.Timeout.String())
 		}
 	}
@@ -306,6 +349,13 @@ func (c *WebsocketClient) handlerProxy(resp *types.WebsocketResponse, err error)
 	}
 }
 
+// observeHandlerQueueDepth reports the handler queue depth to metrics, if set
+func (c *WebsocketClient) observeHandlerQueueDepth(depth int64) {
+	if c.metrics != nil {
+		c.metrics.ObserveHandlerQueueDepth(int(depth))
+	}
+}
+
 // AddDisconnectHandler the function to call when the client is disconnected
 func (c *WebsocketClient) AddDisconnectHandler(onDisconnect rpcinterface.DisconnectHandler) {
 	c.disconnectHandlers = append(c.disconnectHandlers, onDisconnect)
@@ -322,6 +372,11 @@ func (c *WebsocketClient) SetSyncMode() {
 	c.syncMode = true
 }
 
+// SyncMode returns true if the client waits for responses, false in async mode
+func (c *WebsocketClient) SyncMode() bool {
+	return c.syncMode
+}
+
 // SetAsyncMode sets the client to async mode (default)
 // RPC method calls return empty versions of the response objects, and you must have your own
 // listeners to get the responses and handle them
@@ -338,6 +393,9 @@ func (c *WebsocketClient) reconnectLoop() {
 		err := c.ensureConnection()
 		if err == nil {
 			c.logger.Info("Reconnected!")
+			if c.metrics != nil {
+				c.metrics.ObserveWebsocketReconnect()
+			}
 			for topic := range c.subscriptions {
 				err = c.doSubscribe(topic)
 				if err != nil {
@@ -373,6 +431,7 @@ func (c *WebsocketClient) generateDialer() error {
 	if c.daemonDialer == nil {
 		c.daemonDialer = &websocket.Dialer{
 			Proxy:            http.ProxyFromEnvironment,
+			NetDialContext:   c.dialContext,
 			HandshakeTimeout: 45 * time.Second,
 			TLSClientConfig: &tls.Config{
 				Certificates:       []tls.Certificate{*c.daemonKeyPair},
@@ -386,10 +445,15 @@ func (c *WebsocketClient) generateDialer() error {
 
 // ensureConnection ensures there is an open websocket connection and the listener is listening
 func (c *WebsocketClient) ensureConnection() error {
+	return c.ensureConnectionContext(context.Background())
+}
+
+// ensureConnectionContext is ensureConnection, but the dial is aborted if ctx is cancelled
+func (c *WebsocketClient) ensureConnectionContext(ctx context.Context) error {
 	if c.conn == nil {
 		u := url.URL{Scheme: "wss", Host: fmt.Sprintf("%s:%d", c.baseURL.Host, c.daemonPort), Path: "/"}
 		var err error
-		c.conn, _, err = c.daemonDialer.Dial(u.String(), nil)
+		c.conn, _, err = c.daemonDialer.DialContext(ctx, u.String(), nil)
 		if err != nil {
 			return err
 		}
@@ -449,7 +513,13 @@ func (c *WebsocketClient) listen() {
 			err := json.Unmarshal(message, resp)
 			// Has to be called in goroutine so that the handler can potentially call cancel, which
 			// this select needs to also read in order to properly cancel
-			go c.handlerProxy(resp, err)
+			c.observeHandlerQueueDepth(c.handlerQueueDepth.Add(1))
+			go func() {
+				defer func() {
+					c.observeHandlerQueueDepth(c.handlerQueueDepth.Add(-1))
+				}()
+				c.handlerProxy(resp, err)
+			}()
 		}
 	}
 }